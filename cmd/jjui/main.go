@@ -1,11 +1,9 @@
 package main
 
 import (
-	"errors"
 	"flag"
 	"fmt"
 	"io"
-	"io/fs"
 	"log"
 	"os"
 	"os/exec"
@@ -13,7 +11,6 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
-	"github.com/idursun/jjui/internal/ui/common"
 
 	"github.com/idursun/jjui/internal/config"
 	"github.com/idursun/jjui/internal/ui/context"
@@ -37,12 +34,15 @@ func getVersion() string {
 }
 
 var (
-	revset     string
-	period     int
-	limit      int
-	version    bool
-	editConfig bool
-	help       bool
+	revset      string
+	period      int
+	limit       int
+	version     bool
+	editConfig  bool
+	checkConfig bool
+	showConfig  bool
+	listActions bool
+	help        bool
 )
 
 func init() {
@@ -54,6 +54,9 @@ func init() {
 	flag.IntVar(&limit, "n", 0, "Number of revisions to show (alias for --limit)")
 	flag.BoolVar(&version, "version", false, "Show version information")
 	flag.BoolVar(&editConfig, "config", false, "Open configuration file in $EDITOR")
+	flag.BoolVar(&checkConfig, "check-config", false, "Validate the configuration file and report problems")
+	flag.BoolVar(&showConfig, "show-config", false, "Print the effective configuration and the layer each value comes from")
+	flag.BoolVar(&listActions, "list-actions", false, "List the actions leader entries, custom commands and plugins can invoke")
 	flag.BoolVar(&help, "help", false, "Show help information")
 
 	flag.Usage = func() {
@@ -73,6 +76,78 @@ func getJJRootDir(location string) (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
+// loadConfigLayers returns the config layers that apply to the location, only the user config applies outside a repository
+func loadConfigLayers(location string) ([]config.Layer, error) {
+	rootLocation, err := getJJRootDir(location)
+	if err != nil {
+		user, err := config.LoadUserLayer()
+		if err != nil {
+			return nil, err
+		}
+		return []config.Layer{user}, nil
+	}
+	return context.NewAppContext(rootLocation).ConfigLayers()
+}
+
+func runCheckConfig(location string) int {
+	layers, err := loadConfigLayers(location)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	issues, err := context.ValidateLayers(layers)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if len(issues) == 0 {
+		fmt.Println("Configuration is valid.")
+		return 0
+	}
+	for _, issue := range issues {
+		fmt.Println(issue)
+	}
+	fmt.Printf("Found %d problem(s).\n", len(issues))
+	return 1
+}
+
+func runShowConfig(location string) int {
+	layers, err := loadConfigLayers(location)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	origins, err := config.Origins(append([]config.Layer{config.EmbeddedLayer()}, layers...))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	for _, origin := range origins {
+		fmt.Printf("%s = %s  # %s\n", origin.Key, origin.Value, origin.Layer)
+	}
+	return 0
+}
+
+func runListActions(location string) int {
+	layers, err := loadConfigLayers(location)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	loaded, err := context.LoadConfig(layers, true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	config.Current = loaded.Config
+	ctx := &context.MainContext{CustomCommands: loaded.CustomCommands}
+	bound := config.Current.Keys.Actions()
+	for _, name := range ctx.ActionNames() {
+		fmt.Printf("%-36s %s\n", name, config.JoinKeys(bound[name]))
+	}
+	return 0
+}
+
 func main() {
 	flag.Parse()
 	switch {
@@ -101,6 +176,15 @@ func main() {
 		}
 	}
 
+	switch {
+	case checkConfig:
+		os.Exit(runCheckConfig(location))
+	case showConfig:
+		os.Exit(runShowConfig(location))
+	case listActions:
+		os.Exit(runListActions(location))
+	}
+
 	rootLocation, err := getJJRootDir(location)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: There is no jj repo in \"%s\".\n", location)
@@ -118,71 +202,30 @@ func main() {
 		log.SetOutput(io.Discard)
 	}
 
-	if limit > 0 {
-		config.Current.Limit = limit
-	}
-
 	appContext := context.NewAppContext(rootLocation)
 	defer appContext.Histories.Flush()
-	if output, err := config.LoadConfigFile(); err == nil {
-		if err := config.Current.Load(string(output)); err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
-			os.Exit(1)
-		}
-		if registry, err := context.LoadCustomCommands(string(output)); err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading custom commands: %v\n", err)
-			os.Exit(1)
-		} else {
-			appContext.CustomCommands = registry
+	appContext.DarkBackground = lipgloss.HasDarkBackground()
+	appContext.ConfigOverrides = func(c *config.Config) {
+		if limit > 0 {
+			c.Limit = limit
 		}
-		if registry, err := context.LoadLeader(string(output)); err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading leader keys: %v\n", err)
-			os.Exit(1)
-		} else {
-			appContext.Leader = registry
+		if period >= 0 {
+			c.UI.AutoRefreshInterval = period
 		}
-	} else if !errors.Is(err, fs.ErrNotExist) {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
 	}
 
-	var theme map[string]config.Color
-
-	var defaultThemeName string
-	if lipgloss.HasDarkBackground() {
-		defaultThemeName = "default_dark"
-	} else {
-		defaultThemeName = "default_light"
-	}
-
-	theme, err = config.LoadEmbeddedTheme(defaultThemeName)
+	layers, err := appContext.ConfigLayers()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading default theme '%s': %v\n", defaultThemeName, err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
-
-	var userThemeName string
-	if lipgloss.HasDarkBackground() {
-		userThemeName = config.Current.UI.Theme.Dark
-	} else {
-		userThemeName = config.Current.UI.Theme.Light
-	}
-
-	if userThemeName != "" {
-		theme, err = config.LoadTheme(userThemeName, theme)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading user theme '%s': %v\n", userThemeName, err)
-			os.Exit(1)
-		}
+	loaded, err := context.LoadConfig(layers, appContext.DarkBackground)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
+	appContext.ApplyConfig(loaded)
 
-	common.DefaultPalette.Update(theme)
-	common.DefaultPalette.Update(appContext.JJConfig.GetApplicableColors())
-	common.DefaultPalette.Update(config.Current.UI.Colors)
-
-	if period >= 0 {
-		config.Current.UI.AutoRefreshInterval = period
-	}
 	if revset != "" {
 		appContext.DefaultRevset = revset
 	} else if config.Current.Revisions.Revset != "" {
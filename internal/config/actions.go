@@ -0,0 +1,22 @@
+package config
+
+import "strings"
+
+// Actions names every action of the key mappings and returns the keys bound to it.
+// Top level actions are named `revisions.<action>`, e.g. `revisions.new`, and so are the keys that
+// enter a mode, e.g. `revisions.rebase`. The actions inside a mode are named `<mode>.<action>`, e.g. `git.push`.
+func (k KeyMappings[T]) Actions() map[string][]string {
+	actions := map[string][]string{}
+	k.walk(func(group string, action string, bound keys) {
+		actions[ActionName(group, action)] = bound
+	})
+	return actions
+}
+
+// ActionName names the action of a key mapping group as visited by walk
+func ActionName(group string, action string) string {
+	if group == "keys" {
+		return "revisions." + strings.TrimSuffix(action, ".mode")
+	}
+	return strings.TrimPrefix(group, "keys.") + "." + action
+}
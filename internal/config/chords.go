@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// IsChord reports whether the key is a sequence of keys separated by spaces, e.g. "g p"
+func IsChord(k string) bool {
+	return len(ChordKeys(k)) > 1
+}
+
+// ChordKeys splits a chord into the keys it is made of, "space" stands for the space key
+func ChordKeys(chord string) []string {
+	if strings.TrimSpace(chord) == "" {
+		return []string{chord}
+	}
+	fields := strings.Fields(chord)
+	for i, f := range fields {
+		if f == "space" {
+			fields[i] = " "
+		}
+	}
+	return fields
+}
+
+// Chords returns every chord bound in the key mappings
+func (k KeyMappings[T]) Chords() []string {
+	var chords []string
+	k.walk(func(_ string, _ string, bound keys) {
+		for _, b := range bound {
+			if IsChord(b) && !slices.Contains(chords, b) {
+				chords = append(chords, b)
+			}
+		}
+	})
+	return chords
+}
+
+// chordOverlaps finds keys which are also the beginning of a chord. Chords are resolved before the
+// key reaches any mode, so such keys only fire once the chord times out.
+func (k KeyMappings[T]) chordOverlaps() []string {
+	type binding struct {
+		action string
+		key    string
+	}
+	var bindings []binding
+	k.walk(func(group string, action string, bound keys) {
+		if group != "keys" {
+			action = strings.TrimPrefix(group, "keys.") + "." + action
+		}
+		for _, b := range bound {
+			bindings = append(bindings, binding{action: action, key: strings.Join(ChordKeys(b), " ")})
+		}
+	})
+
+	var issues []string
+	for _, chord := range bindings {
+		chordKeys := strings.Split(chord.key, " ")
+		if len(chordKeys) < 2 {
+			continue
+		}
+		for _, other := range bindings {
+			otherKeys := strings.Split(other.key, " ")
+			if len(otherKeys) < len(chordKeys) && slices.Equal(chordKeys[:len(otherKeys)], otherKeys) {
+				issues = append(issues, fmt.Sprintf("keys: %q (%s) is a prefix of the chord %q (%s) and only fires after the chord timeout", other.key, other.action, chord.key, chord.action))
+			}
+		}
+	}
+	return issues
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"strings"
 )
 
 //go:embed default/*.toml
@@ -117,29 +118,162 @@ type TracerConfig struct {
 	Enabled bool `toml:"enabled"`
 }
 
+// WhichKeyConfig configures the popup listing the keys of the leader menu
+type WhichKeyConfig struct {
+	Enabled bool `toml:"enabled"`
+	// Delay is how long (in milliseconds) to wait after a leader key before showing the popup
+	Delay int `toml:"delay"`
+}
+
+// RepoWatcherConfig configures refreshing when an operation lands or the working copy changes, e.g. after
+// running jj outside jjui. It is an alternative to auto_refresh_interval which runs jj on every check.
+type RepoWatcherConfig struct {
+	Enabled bool `toml:"enabled"`
+	// Interval is how often (in milliseconds) the repository is checked for changes
+	Interval int `toml:"interval"`
+	// Debounce is how long (in milliseconds) the repository has to stay unchanged before refreshing
+	Debounce int `toml:"debounce"`
+	// MaxFiles is the largest working copy whose files are watched, 0 only watches for new operations
+	MaxFiles int `toml:"max_files"`
+	// WorkingCopyInterval is how often (in milliseconds) the working copy files are checked for changes
+	WorkingCopyInterval int `toml:"working_copy_interval"`
+}
+
 type UIConfig struct {
 	Theme  ThemeConfig      `toml:"theme"`
 	Colors map[string]Color `toml:"colors"`
 	// TODO(ilyagr): It might make sense to rename this to `auto_refresh_period` to match `--period` option
 	// once we have a mechanism to deprecate the old name softly.
-	AutoRefreshInterval int          `toml:"auto_refresh_interval"`
-	Tracer              TracerConfig `toml:"tracer"`
+	AutoRefreshInterval int `toml:"auto_refresh_interval"`
+	// ConfigReloadInterval is how often (in seconds) config.toml and the theme file are checked for changes, 0 disables it
+	ConfigReloadInterval int `toml:"config_reload_interval"`
+	// ChordTimeout is how long (in milliseconds) to wait for the next key of a multi-key binding like "g p"
+	ChordTimeout int `toml:"chord_timeout"`
+	// PluginTimeout is how long (in milliseconds) a plugin can run before it is stopped, 0 never stops it
+	PluginTimeout int               `toml:"plugin_timeout"`
+	Tracer        TracerConfig      `toml:"tracer"`
+	WhichKey      WhichKeyConfig    `toml:"which_key"`
+	RepoWatcher   RepoWatcherConfig `toml:"repo_watcher"`
 }
 
 type RevisionsConfig struct {
-	LogBatching bool   `toml:"log_batching"`
-	Template    string `toml:"template"`
-	Revset      string `toml:"revset"`
+	LogBatching    bool              `toml:"log_batching"`
+	Template       string            `toml:"template"`
+	Revset         string            `toml:"revset"`
+	ShowSignatures bool              `toml:"show_signatures"`
+	QuickSearch    QuickSearchConfig `toml:"quick_search"`
+}
+
+// QuickSearchConfig configures how the quick search text is matched against the revisions
+type QuickSearchConfig struct {
+	IgnoreCase bool `toml:"ignore_case"`
+	// Regex treats the search text as a regular expression
+	Regex bool `toml:"regex"`
+}
+
+// PreviewCommand is a named view of the preview pane. It runs either jj with Command as its arguments or
+// the Shell command line through `$SHELL -c`, with the placeholders exported as environment variables.
+type PreviewCommand struct {
+	Name    string   `toml:"name"`
+	Command []string `toml:"command"`
+	Shell   string   `toml:"shell"`
+}
+
+// PreviewCommands are the views the preview pane cycles through for an item type. They are written either
+// as a single command, e.g. `["show", "-r", "$change_id"]`, or as a list of named commands, e.g.
+// `[{ name = "stat", command = ["show", "--stat", "-r", "$change_id"] }]`. A command without a name is
+// named after its first argument, or the first word of its shell command line.
+type PreviewCommands []PreviewCommand
+
+func (p *PreviewCommands) UnmarshalTOML(data any) error {
+	var values []any
+	switch v := data.(type) {
+	case []any:
+		values = v
+	case []map[string]any:
+		for _, table := range v {
+			values = append(values, table)
+		}
+	default:
+		return fmt.Errorf("invalid preview command: expected a list, got %T", data)
+	}
+	*p = nil
+	var single []string
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			single = append(single, v)
+		case map[string]any:
+			command, err := previewCommand(v)
+			if err != nil {
+				return err
+			}
+			*p = append(*p, command)
+		default:
+			return fmt.Errorf("invalid preview command: expected a string or a table, got %T", value)
+		}
+	}
+	if single != nil && *p != nil {
+		return fmt.Errorf("invalid preview command: a list is either a single command or named commands")
+	}
+	if single != nil {
+		*p = PreviewCommands{{Name: single[0], Command: single}}
+	}
+	return nil
+}
+
+func previewCommand(table map[string]any) (PreviewCommand, error) {
+	var command PreviewCommand
+	if name, ok := table["name"]; ok {
+		nameStr, isString := name.(string)
+		if !isString {
+			return command, fmt.Errorf("invalid type for 'name' of preview command: expected string, got %T", name)
+		}
+		command.Name = nameStr
+	}
+	args, _ := table["command"].([]any)
+	for _, arg := range args {
+		argStr, isString := arg.(string)
+		if !isString {
+			return command, fmt.Errorf("invalid type for 'command' of preview command: expected strings, got %T", arg)
+		}
+		command.Command = append(command.Command, argStr)
+	}
+	if shell, ok := table["shell"]; ok {
+		shellStr, isString := shell.(string)
+		if !isString {
+			return command, fmt.Errorf("invalid type for 'shell' of preview command: expected string, got %T", shell)
+		}
+		command.Shell = strings.TrimSpace(shellStr)
+	}
+	switch {
+	case len(command.Command) == 0 && command.Shell == "":
+		return command, fmt.Errorf("preview command %q has no command to run", command.Name)
+	case len(command.Command) > 0 && command.Shell != "":
+		return command, fmt.Errorf("preview command %q has both a command and a shell command line", command.Name)
+	}
+	if command.Name == "" && command.Shell != "" {
+		command.Name = strings.Fields(command.Shell)[0]
+	} else if command.Name == "" {
+		command.Name = command.Command[0]
+	}
+	return command, nil
 }
 
 type PreviewConfig struct {
-	RevisionCommand          []string `toml:"revision_command"`
-	OplogCommand             []string `toml:"oplog_command"`
-	FileCommand              []string `toml:"file_command"`
-	ShowAtStart              bool     `toml:"show_at_start"`
-	ShowAtBottom             bool     `toml:"show_at_bottom"`
-	WidthPercentage          float64  `toml:"width_percentage"`
-	WidthIncrementPercentage float64  `toml:"width_increment_percentage"`
+	RevisionCommand          PreviewCommands `toml:"revision_command"`
+	OplogCommand             PreviewCommands `toml:"oplog_command"`
+	FileCommand              PreviewCommands `toml:"file_command"`
+	ShowAtStart              bool            `toml:"show_at_start"`
+	ShowAtBottom             bool            `toml:"show_at_bottom"`
+	WidthPercentage          float64         `toml:"width_percentage"`
+	WidthIncrementPercentage float64         `toml:"width_increment_percentage"`
+	// Wrap soft-wraps long lines, otherwise they are cut and can be scrolled horizontally
+	Wrap bool `toml:"wrap"`
+	// CacheSize is how many previews are kept so that going back to a recent row shows its preview instantly
+	CacheSize int `toml:"cache_size"`
+	// Prefetch loads the previews of the rows next to the selected one in the background
+	Prefetch bool `toml:"prefetch"`
 }
 
 type OpLogConfig struct {
@@ -155,17 +289,21 @@ type ShowOption string
 const (
 	ShowOptionDiff        ShowOption = "diff"
 	ShowOptionInteractive ShowOption = "interactive"
+	ShowOptionFlash       ShowOption = "flash"
+	ShowOptionPreview     ShowOption = "preview"
 )
 
 func (s *ShowOption) UnmarshalText(text []byte) error {
 	val := string(text)
 	switch val {
 	case string(ShowOptionDiff),
-		string(ShowOptionInteractive):
+		string(ShowOptionInteractive),
+		string(ShowOptionFlash),
+		string(ShowOptionPreview):
 		*s = ShowOption(val)
 		return nil
 	default:
-		return fmt.Errorf("invalid value for 'show': %q. Allowed: none, interactive and diff", val)
+		return fmt.Errorf("invalid value for 'show': %q. Allowed: none, interactive, diff, flash and preview", val)
 	}
 }
 
@@ -57,6 +57,48 @@ auto_refresh_interval = 5000
 	assert.Equal(t, 5000, config.UI.AutoRefreshInterval)
 }
 
+func TestLoad_PreviewCommands(t *testing.T) {
+	content := `
+[preview]
+revision_command = ["show", "-r", "$change_id"]
+file_command = [
+  { name = "diff", command = ["diff", "-r", "$change_id", "$file"] },
+  { command = ["file", "show", "-r", "$change_id", "$file"] },
+]
+
+[[preview.oplog_command]]
+name = "patch"
+command = ["op", "show", "$operation_id", "--patch"]
+
+[[preview.oplog_command]]
+shell = "jj op show $operation_id | less"
+`
+	config := &Config{}
+	err := config.Load(content)
+	assert.NoError(t, err)
+	assert.Equal(t, PreviewCommands{{Name: "show", Command: []string{"show", "-r", "$change_id"}}}, config.Preview.RevisionCommand)
+	assert.Equal(t, PreviewCommands{
+		{Name: "diff", Command: []string{"diff", "-r", "$change_id", "$file"}},
+		{Name: "file", Command: []string{"file", "show", "-r", "$change_id", "$file"}},
+	}, config.Preview.FileCommand)
+	assert.Equal(t, PreviewCommands{
+		{Name: "patch", Command: []string{"op", "show", "$operation_id", "--patch"}},
+		{Name: "jj", Shell: "jj op show $operation_id | less"},
+	}, config.Preview.OplogCommand)
+
+	err = (&Config{}).Load(`
+[preview]
+revision_command = [{ name = "empty" }]
+`)
+	assert.ErrorContains(t, err, `preview command "empty" has no command to run`)
+
+	err = (&Config{}).Load(`
+[preview]
+revision_command = [{ name = "both", command = ["show"], shell = "jj show" }]
+`)
+	assert.ErrorContains(t, err, `preview command "both" has both a command and a shell command line`)
+}
+
 func TestLoad_Colors_StringAndObject(t *testing.T) {
 	content := `
 [ui.colors]
@@ -76,3 +118,67 @@ complex = { fg = "blue", bg = "white", bold = true }
 	assert.Equal(t, "white", config.UI.Colors["complex"].Bg)
 	assert.True(t, config.UI.Colors["complex"].Bold)
 }
+
+func TestValidate_UnknownKeys(t *testing.T) {
+	issues, err := Validate(`
+[revisions]
+templat = "builtin_log_compact"
+
+[custom_commands."show diff"]
+args = ["diff"]
+`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"unknown key: revisions.templat"}, issues)
+}
+
+func TestValidate_ConflictingKeys(t *testing.T) {
+	issues, err := Validate(`
+[keys]
+new = ["a"]
+
+[keys.rebase]
+revision = ["s"]
+`)
+	assert.NoError(t, err)
+	assert.Len(t, issues, 2)
+	assert.Contains(t, issues[0], `keys: "a" is bound to more than one action`)
+	assert.Contains(t, issues[1], `keys.rebase: "s" is bound to more than one action`)
+}
+
+func TestValidate_DefaultConfig(t *testing.T) {
+	issues, err := Validate("")
+	assert.NoError(t, err)
+	assert.Empty(t, issues)
+}
+
+func TestValidate_ChordOverlaps(t *testing.T) {
+	issues, err := Validate(`
+[keys]
+abandon = ["Z d"]
+new = ["Z"]
+`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`keys: "Z" (new) is a prefix of the chord "Z d" (abandon) and only fires after the chord timeout`}, issues)
+}
+
+func TestChords(t *testing.T) {
+	c := loadDefaultConfig()
+	c.Keys.Git.Push = keys{"g p", "P"}
+	assert.Equal(t, []string{"g p"}, c.Keys.Chords())
+	assert.Equal(t, "g p/P", JoinKeys(c.Keys.Git.Push))
+	assert.Equal(t, "space ↑", JoinKeys(keys{"space up"}))
+	assert.Equal(t, "space", JoinKeys(keys{" "}))
+}
+
+func TestActions(t *testing.T) {
+	k := loadDefaultConfig().Keys
+	actions := k.Actions()
+	assert.Equal(t, []string(k.New), actions["revisions.new"])
+	assert.Equal(t, []string(k.Rebase.Mode), actions["revisions.rebase"])
+	assert.Equal(t, []string(k.Rebase.Onto), actions["rebase.onto"])
+	assert.Equal(t, []string(k.Git.Push), actions["git.push"])
+
+	bindings := 0
+	k.walk(func(string, string, keys) { bindings++ })
+	assert.Len(t, actions, bindings, "every key binding has an action of its own")
+}
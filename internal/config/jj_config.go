@@ -1,6 +1,10 @@
 package config
 
 import (
+	"maps"
+	"slices"
+	"strings"
+
 	"github.com/BurntSushi/toml"
 )
 
@@ -10,6 +14,39 @@ type JJConfig struct {
 	Revsets       struct {
 		Log string `toml:"log"`
 	} `toml:"revsets"`
+	Fix struct {
+		Tools map[string]FixTool `toml:"tools"`
+	} `toml:"fix"`
+}
+
+type FixTool struct {
+	Name     string   `toml:"-"`
+	Command  []string `toml:"command"`
+	Patterns []string `toml:"patterns"`
+	Enabled  *bool    `toml:"enabled"`
+}
+
+func (t FixTool) IsEnabled() bool {
+	return t.Enabled == nil || *t.Enabled
+}
+
+// Fileset combines the patterns the same way `jj fix` does
+func (t FixTool) Fileset() string {
+	return strings.Join(t.Patterns, " | ")
+}
+
+// GetFixTools returns the configured `fix.tools` sorted by name
+func (c *JJConfig) GetFixTools() []FixTool {
+	if c == nil {
+		return nil
+	}
+	var tools []FixTool
+	for _, name := range slices.Sorted(maps.Keys(c.Fix.Tools)) {
+		tool := c.Fix.Tools[name]
+		tool.Name = name
+		tools = append(tools, tool)
+	}
+	return tools
 }
 
 func (c *JJConfig) GetApplicableColors() map[string]Color {
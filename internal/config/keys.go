@@ -44,10 +44,12 @@ func Convert(m KeyMappings[keys]) KeyMappings[key.Binding] {
 		Leader:           key.NewBinding(key.WithKeys(m.Leader...), key.WithHelp(JoinKeys(m.Leader), "leader")),
 		Suspend:          key.NewBinding(key.WithKeys(m.Suspend...), key.WithHelp(JoinKeys(m.Suspend), "suspend")),
 		SetParents:       key.NewBinding(key.WithKeys(m.SetParents...), key.WithHelp(JoinKeys(m.SetParents), "set parents")),
+		Fix:              key.NewBinding(key.WithKeys(m.Fix...), key.WithHelp(JoinKeys(m.Fix), "fix")),
+		Sign:             key.NewBinding(key.WithKeys(m.Sign...), key.WithHelp(JoinKeys(m.Sign), "sign/unsign")),
 		ExecJJ:           key.NewBinding(key.WithKeys(m.ExecJJ...), key.WithHelp(JoinKeys(m.ExecJJ), "interactive jj")),
 		ExecShell:        key.NewBinding(key.WithKeys(m.ExecShell...), key.WithHelp(JoinKeys(m.ExecShell), "interactive shell command")),
 		Revert: revertModeKeys[key.Binding]{
-			Mode:   key.NewBinding(key.WithKeys(m.Revert.Mode...), key.WithHelp(JoinKeys(m.Revert.Mode), "rebase")),
+			Mode:   key.NewBinding(key.WithKeys(m.Revert.Mode...), key.WithHelp(JoinKeys(m.Revert.Mode), "revert")),
 			After:  key.NewBinding(key.WithKeys(m.Revert.After...), key.WithHelp(JoinKeys(m.Revert.After), "insert after")),
 			Before: key.NewBinding(key.WithKeys(m.Revert.Before...), key.WithHelp(JoinKeys(m.Revert.Before), "insert before")),
 			Onto:   key.NewBinding(key.WithKeys(m.Revert.Onto...), key.WithHelp(JoinKeys(m.Revert.Onto), "onto")),
@@ -64,6 +66,13 @@ func Convert(m KeyMappings[keys]) KeyMappings[key.Binding] {
 			Insert:      key.NewBinding(key.WithKeys(m.Rebase.Insert...), key.WithHelp(JoinKeys(m.Rebase.Insert), "insert between")),
 			SkipEmptied: key.NewBinding(key.WithKeys(m.Rebase.SkipEmptied...), key.WithHelp(JoinKeys(m.Rebase.SkipEmptied), "skip emptied")),
 		},
+		NewPlacement: newPlacementModeKeys[key.Binding]{
+			After:        key.NewBinding(key.WithKeys(m.NewPlacement.After...), key.WithHelp(JoinKeys(m.NewPlacement.After), "insert after")),
+			Before:       key.NewBinding(key.WithKeys(m.NewPlacement.Before...), key.WithHelp(JoinKeys(m.NewPlacement.Before), "insert before")),
+			Onto:         key.NewBinding(key.WithKeys(m.NewPlacement.Onto...), key.WithHelp(JoinKeys(m.NewPlacement.Onto), "onto")),
+			Insert:       key.NewBinding(key.WithKeys(m.NewPlacement.Insert...), key.WithHelp(JoinKeys(m.NewPlacement.Insert), "insert between")),
+			ToggleParent: key.NewBinding(key.WithKeys(m.NewPlacement.ToggleParent...), key.WithHelp(JoinKeys(m.NewPlacement.ToggleParent), "toggle parent")),
+		},
 		Duplicate: duplicateModeKeys[key.Binding]{
 			Mode:   key.NewBinding(key.WithKeys(m.Duplicate.Mode...), key.WithHelp(JoinKeys(m.Duplicate.Mode), "duplicate")),
 			After:  key.NewBinding(key.WithKeys(m.Duplicate.After...), key.WithHelp(JoinKeys(m.Duplicate.After), "duplicate after")),
@@ -104,6 +113,13 @@ func Convert(m KeyMappings[keys]) KeyMappings[key.Binding] {
 			HalfPageUp:   key.NewBinding(key.WithKeys(m.Preview.HalfPageUp...), key.WithHelp(JoinKeys(m.Preview.HalfPageUp), "preview half page up")),
 			Expand:       key.NewBinding(key.WithKeys(m.Preview.Expand...), key.WithHelp(JoinKeys(m.Preview.Expand), "expand width")),
 			Shrink:       key.NewBinding(key.WithKeys(m.Preview.Shrink...), key.WithHelp(JoinKeys(m.Preview.Shrink), "shrink width")),
+			Search:       key.NewBinding(key.WithKeys(m.Preview.Search...), key.WithHelp(JoinKeys(m.Preview.Search), "preview search")),
+			SearchNext:   key.NewBinding(key.WithKeys(m.Preview.SearchNext...), key.WithHelp(JoinKeys(m.Preview.SearchNext), "preview next match")),
+			SearchPrev:   key.NewBinding(key.WithKeys(m.Preview.SearchPrev...), key.WithHelp(JoinKeys(m.Preview.SearchPrev), "preview previous match")),
+			ToggleWrap:   key.NewBinding(key.WithKeys(m.Preview.ToggleWrap...), key.WithHelp(JoinKeys(m.Preview.ToggleWrap), "preview toggle wrap")),
+			ScrollLeft:   key.NewBinding(key.WithKeys(m.Preview.ScrollLeft...), key.WithHelp(JoinKeys(m.Preview.ScrollLeft), "preview scroll left")),
+			ScrollRight:  key.NewBinding(key.WithKeys(m.Preview.ScrollRight...), key.WithHelp(JoinKeys(m.Preview.ScrollRight), "preview scroll right")),
+			CycleView:    key.NewBinding(key.WithKeys(m.Preview.CycleView...), key.WithHelp(JoinKeys(m.Preview.CycleView), "preview next view")),
 		},
 		Git: gitModeKeys[key.Binding]{
 			Mode:  key.NewBinding(key.WithKeys(m.Git.Mode...), key.WithHelp(JoinKeys(m.Git.Mode), "git")),
@@ -126,6 +142,20 @@ func Convert(m KeyMappings[keys]) KeyMappings[key.Binding] {
 			Accept: key.NewBinding(key.WithKeys(m.FileSearch.Accept...), key.WithHelp(JoinKeys(m.FileSearch.Accept), "file revset")),
 			Edit:   key.NewBinding(key.WithKeys(m.FileSearch.Edit...), key.WithHelp(JoinKeys(m.FileSearch.Edit), "edit file")),
 		},
+		Metadata: metadataModeKeys[key.Binding]{
+			Mode:        key.NewBinding(key.WithKeys(m.Metadata.Mode...), key.WithHelp(JoinKeys(m.Metadata.Mode), "edit author")),
+			NextField:   key.NewBinding(key.WithKeys(m.Metadata.NextField...), key.WithHelp(JoinKeys(m.Metadata.NextField), "next field")),
+			ResetAuthor: key.NewBinding(key.WithKeys(m.Metadata.ResetAuthor...), key.WithHelp(JoinKeys(m.Metadata.ResetAuthor), "reset author")),
+		},
+		Palette: paletteModeKeys[key.Binding]{
+			Mode:      key.NewBinding(key.WithKeys(m.Palette.Mode...), key.WithHelp(JoinKeys(m.Palette.Mode), "palette inspector")),
+			NextTheme: key.NewBinding(key.WithKeys(m.Palette.NextTheme...), key.WithHelp(JoinKeys(m.Palette.NextTheme), "next theme")),
+			PrevTheme: key.NewBinding(key.WithKeys(m.Palette.PrevTheme...), key.WithHelp(JoinKeys(m.Palette.PrevTheme), "previous theme")),
+		},
+		CommandStats: commandStatsModeKeys[key.Binding]{
+			Mode:   key.NewBinding(key.WithKeys(m.CommandStats.Mode...), key.WithHelp(JoinKeys(m.CommandStats.Mode), "command stats")),
+			Export: key.NewBinding(key.WithKeys(m.CommandStats.Export...), key.WithHelp(JoinKeys(m.CommandStats.Export), "export chrome trace")),
+		},
 		Copy: copyModeKeys[key.Binding]{
 			Mode:        key.NewBinding(key.WithKeys(m.Copy.Mode...), key.WithHelp(JoinKeys(m.Copy.Mode), "copy")),
 			ChangeId:    key.NewBinding(key.WithKeys(m.Copy.ChangeId...), key.WithHelp(JoinKeys(m.Copy.ChangeId), "copy change ID")),
@@ -143,20 +173,31 @@ func (c *Config) GetKeyMap() KeyMappings[key.Binding] {
 func JoinKeys(keys []string) string {
 	var joined []string
 	for _, key := range keys {
-		k := key
-		switch key {
-		case "up":
-			k = "↑"
-		case "down":
-			k = "↓"
-		case " ":
-			k = "space"
+		if IsChord(key) {
+			var chord []string
+			for _, k := range ChordKeys(key) {
+				chord = append(chord, displayKey(k))
+			}
+			joined = append(joined, strings.Join(chord, " "))
+			continue
 		}
-		joined = append(joined, k)
+		joined = append(joined, displayKey(key))
 	}
 	return strings.Join(joined, "/")
 }
 
+func displayKey(key string) string {
+	switch key {
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case " ":
+		return "space"
+	}
+	return key
+}
+
 type keys []string
 
 type KeyMappings[T any] struct {
@@ -193,9 +234,12 @@ type KeyMappings[T any] struct {
 	Leader            T                         `toml:"leader"`
 	Suspend           T                         `toml:"suspend"`
 	SetParents        T                         `toml:"set_parents"`
+	Fix               T                         `toml:"fix"`
+	Sign              T                         `toml:"sign"`
 	Revert            revertModeKeys[T]         `toml:"revert"`
 	Rebase            rebaseModeKeys[T]         `toml:"rebase"`
 	Duplicate         duplicateModeKeys[T]      `toml:"duplicate"`
+	NewPlacement      newPlacementModeKeys[T]   `toml:"new_placement"`
 	Squash            squashModeKeys[T]         `toml:"squash"`
 	Details           detailsModeKeys[T]        `toml:"details"`
 	Evolog            evologModeKeys[T]         `toml:"evolog"`
@@ -206,6 +250,9 @@ type KeyMappings[T any] struct {
 	OpLog             opLogModeKeys[T]          `toml:"oplog"`
 	FileSearch        fileSearchKeys[T]         `toml:"file_search"`
 	Copy              copyModeKeys[T]           `toml:"copy"`
+	Metadata          metadataModeKeys[T]       `toml:"metadata"`
+	Palette           paletteModeKeys[T]        `toml:"palette"`
+	CommandStats      commandStatsModeKeys[T]   `toml:"command_stats"`
 }
 
 type bookmarkModeKeys[T any] struct {
@@ -251,6 +298,15 @@ type duplicateModeKeys[T any] struct {
 	Onto   T `toml:"onto"`
 }
 
+// newPlacementModeKeys are the keys of the target picker opened by `new`, it has no mode key of its own
+type newPlacementModeKeys[T any] struct {
+	After        T `toml:"after"`
+	Before       T `toml:"before"`
+	Onto         T `toml:"onto"`
+	Insert       T `toml:"insert"`
+	ToggleParent T `toml:"toggle_parent"`
+}
+
 type evologModeKeys[T any] struct {
 	Mode    T `toml:"mode"`
 	Diff    T `toml:"diff"`
@@ -284,6 +340,13 @@ type previewModeKeys[T any] struct {
 	HalfPageUp   T `toml:"half_page_up"`
 	Expand       T `toml:"expand"`
 	Shrink       T `toml:"shrink"`
+	Search       T `toml:"search"`
+	SearchNext   T `toml:"search_next"`
+	SearchPrev   T `toml:"search_prev"`
+	ToggleWrap   T `toml:"toggle_wrap"`
+	ScrollLeft   T `toml:"scroll_left"`
+	ScrollRight  T `toml:"scroll_right"`
+	CycleView    T `toml:"cycle_view"`
 }
 
 type opLogModeKeys[T any] struct {
@@ -312,3 +375,20 @@ type copyModeKeys[T any] struct {
 	Description T `toml:"description"`
 	FullInfo    T `toml:"full_info"`
 }
+
+type paletteModeKeys[T any] struct {
+	Mode      T `toml:"mode"`
+	NextTheme T `toml:"next_theme"`
+	PrevTheme T `toml:"prev_theme"`
+}
+
+type commandStatsModeKeys[T any] struct {
+	Mode   T `toml:"mode"`
+	Export T `toml:"export"`
+}
+
+type metadataModeKeys[T any] struct {
+	Mode        T `toml:"mode"`
+	NextField   T `toml:"next_field"`
+	ResetAuthor T `toml:"reset_author"`
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	DefaultLayer = "default"
+	UserLayer    = "user"
+	RepoLayer    = "repo"
+)
+
+// Layer is a single source of configuration. Layers are applied in order, later layers win.
+type Layer struct {
+	Name   string
+	Source string
+	Data   string
+}
+
+func (l Layer) String() string {
+	return fmt.Sprintf("%s (%s)", l.Name, l.Source)
+}
+
+// EmbeddedLayer returns the default configuration that ships with jjui
+func EmbeddedLayer() Layer {
+	data, _ := configFS.ReadFile("default/config.toml")
+	return Layer{Name: DefaultLayer, Source: "embedded", Data: string(data)}
+}
+
+// UserConfigFilePath returns the location of the user level config.toml
+func UserConfigFilePath() string {
+	return getConfigFilePath()
+}
+
+// LoadUserLayer reads the user level config.toml, a missing file is an empty layer
+func LoadUserLayer() (Layer, error) {
+	output, err := LoadConfigFile()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return Layer{}, err
+	}
+	return Layer{Name: UserLayer, Source: UserConfigFilePath(), Data: string(output)}, nil
+}
+
+// RepoConfigFilePath returns the location of the repository level jjui.toml
+func RepoConfigFilePath(location string) string {
+	return filepath.Join(location, ".jj", "jjui.toml")
+}
+
+// RepoConfigFiles returns the repository files that may contain jjui configuration
+func RepoConfigFiles(location string) []string {
+	return []string{
+		filepath.Join(location, ".jj", "repo", "config.toml"),
+		RepoConfigFilePath(location),
+	}
+}
+
+// ExtractTable returns the named table from the output of `jj config list` as a standalone toml document
+func ExtractTable(output string, name string) (string, error) {
+	var all map[string]any
+	if _, err := toml.Decode(output, &all); err != nil {
+		return "", err
+	}
+	table, ok := all[name].(map[string]any)
+	if !ok || len(table) == 0 {
+		return "", nil
+	}
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(table); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// LoadLayers decodes the layers on top of a fresh copy of the embedded default config
+func LoadLayers(layers []Layer) (*Config, error) {
+	c := loadDefaultConfig()
+	for _, layer := range layers {
+		if err := c.Load(layer.Data); err != nil {
+			return nil, fmt.Errorf("%s: %w", layer, err)
+		}
+	}
+	return c, nil
+}
+
+// Origin is an effective configuration value and the layer that set it
+type Origin struct {
+	Key   string
+	Value string
+	Layer Layer
+}
+
+// Origins flattens the layers into dotted keys and returns the effective value of each key together with
+// the layer it came from, sorted by key
+func Origins(layers []Layer) ([]Origin, error) {
+	origins := make(map[string]Origin)
+	for _, layer := range layers {
+		var values map[string]any
+		if _, err := toml.Decode(layer.Data, &values); err != nil {
+			return nil, fmt.Errorf("%s: %w", layer, err)
+		}
+		flatten(nil, values, func(key []string, value any) {
+			k := toml.Key(key).String()
+			origins[k] = Origin{Key: k, Value: formatValue(value), Layer: layer}
+		})
+	}
+	var ret []Origin
+	for _, key := range slices.Sorted(maps.Keys(origins)) {
+		ret = append(ret, origins[key])
+	}
+	return ret, nil
+}
+
+func flatten(prefix []string, values map[string]any, visit func(key []string, value any)) {
+	for name, value := range values {
+		key := append(slices.Clone(prefix), name)
+		if table, ok := value.(map[string]any); ok {
+			flatten(key, table, visit)
+			continue
+		}
+		visit(key, value)
+	}
+}
+
+func formatValue(value any) string {
+	// the encoder writes tables in arrays as [[v]] sections, they are written inline instead
+	switch v := value.(type) {
+	case []map[string]any:
+		items := make([]string, 0, len(v))
+		for _, table := range v {
+			items = append(items, formatValue(table))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, formatValue(item))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]any:
+		items := make([]string, 0, len(v))
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			items = append(items, toml.Key{key}.String()+" = "+formatValue(v[key]))
+		}
+		return "{ " + strings.Join(items, ", ") + " }"
+	}
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(map[string]any{"v": value}); err != nil {
+		return fmt.Sprint(value)
+	}
+	return strings.TrimPrefix(strings.TrimSpace(buf.String()), "v = ")
+}
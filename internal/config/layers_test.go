@@ -0,0 +1,58 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractTable(t *testing.T) {
+	output := `jjui.revisions.revset = "mine()"
+jjui.custom_commands."show diff".args = ["diff", "-r", "$change_id"]
+ui.editor = "vim"
+`
+	data, err := ExtractTable(output, "jjui")
+	require.NoError(t, err)
+
+	c, err := LoadLayers([]Layer{{Name: RepoLayer, Data: data}})
+	require.NoError(t, err)
+	assert.Equal(t, "mine()", c.Revisions.Revset)
+	assert.Contains(t, data, "show diff")
+	assert.NotContains(t, data, "editor")
+
+	data, err = ExtractTable(`ui.editor = "vim"`, "jjui")
+	require.NoError(t, err)
+	assert.Empty(t, data)
+}
+
+func TestLoadLayers_LaterLayerWins(t *testing.T) {
+	user := Layer{Name: UserLayer, Source: "config.toml", Data: `
+[revisions]
+revset = "all()"
+[ui.colors]
+"selected" = { fg = "blue" }
+`}
+	repo := Layer{Name: RepoLayer, Source: ".jj/jjui.toml", Data: `
+[revisions]
+revset = "mine()"
+[ui.colors]
+"text" = "white"
+`}
+	c, err := LoadLayers([]Layer{user, repo})
+	require.NoError(t, err)
+	assert.Equal(t, "mine()", c.Revisions.Revset)
+	assert.Equal(t, "blue", c.UI.Colors["selected"].Fg)
+	assert.Equal(t, "white", c.UI.Colors["text"].Fg)
+
+	origins, err := Origins([]Layer{EmbeddedLayer(), user, repo})
+	require.NoError(t, err)
+	byKey := make(map[string]Origin)
+	for _, origin := range origins {
+		byKey[origin.Key] = origin
+	}
+	assert.Equal(t, `"mine()"`, byKey["revisions.revset"].Value)
+	assert.Equal(t, repo, byKey["revisions.revset"].Layer)
+	assert.Equal(t, user, byKey[`ui.colors.selected.fg`].Layer)
+	assert.Equal(t, DefaultLayer, byKey["keys.abandon"].Layer.Name)
+}
@@ -110,10 +110,7 @@ func LoadEmbeddedTheme(name string) (map[string]Color, error) {
 }
 
 func LoadTheme(name string, base map[string]Color) (map[string]Color, error) {
-	configFilePath := getConfigFilePath()
-	themeFile := filepath.Join(filepath.Dir(configFilePath), "themes", name+".toml")
-
-	data, err := os.ReadFile(themeFile)
+	data, err := os.ReadFile(themeFilePath(name))
 	if err != nil {
 		return nil, err
 	}
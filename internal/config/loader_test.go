@@ -1,7 +1,10 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -51,3 +54,29 @@ selected = { fg = "yellow", bg = "blue" }
 
 	assert.EqualExportedValues(t, expected, theme)
 }
+
+func TestLoadConfigKeepsDefaults(t *testing.T) {
+	c, err := LoadConfig(`
+[keys]
+  new = ["N"]
+`)
+	require.NoError(t, err)
+	assert.Equal(t, keys{"N"}, c.Keys.New)
+	assert.Equal(t, keys{"a"}, c.Keys.Abandon)
+
+	_, err = LoadConfig(`[keys`)
+	assert.Error(t, err)
+}
+
+func TestFileWatcher(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.toml")
+	watcher := NewFileWatcher([]string{file})
+	assert.False(t, watcher.Changed())
+
+	require.NoError(t, os.WriteFile(file, []byte("limit = 1"), 0o644))
+	assert.True(t, watcher.Changed())
+	assert.False(t, watcher.Changed())
+
+	require.NoError(t, os.Chtimes(file, time.Now().Add(time.Hour), time.Now().Add(time.Hour)))
+	assert.True(t, watcher.Changed())
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LoadConfig decodes data on top of a fresh copy of the embedded default config
+func LoadConfig(data string) (*Config, error) {
+	c := loadDefaultConfig()
+	if err := c.Load(data); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// ThemeName returns the name of the user theme for the given terminal background
+func (c *Config) ThemeName(darkBackground bool) string {
+	if darkBackground {
+		return c.UI.Theme.Dark
+	}
+	return c.UI.Theme.Light
+}
+
+// ThemeLayer is a set of colors and the layer of the palette they belong to
+type ThemeLayer struct {
+	Name   string
+	Colors map[string]Color
+}
+
+// LoadThemeLayers loads the embedded default theme for the given terminal background
+// and the user theme which goes on top of it
+func (c *Config) LoadThemeLayers(darkBackground bool) ([]ThemeLayer, error) {
+	defaultThemeName := "default_light"
+	if darkBackground {
+		defaultThemeName = "default_dark"
+	}
+	theme, err := LoadEmbeddedTheme(defaultThemeName)
+	if err != nil {
+		return nil, fmt.Errorf("loading default theme '%s': %w", defaultThemeName, err)
+	}
+	layers := []ThemeLayer{{Name: "embedded theme", Colors: theme}}
+	if userThemeName := c.ThemeName(darkBackground); userThemeName != "" {
+		theme, err = LoadTheme(userThemeName, nil)
+		if err != nil {
+			return nil, fmt.Errorf("loading user theme '%s': %w", userThemeName, err)
+		}
+		layers = append(layers, ThemeLayer{Name: "user theme " + userThemeName, Colors: theme})
+	}
+	return layers, nil
+}
+
+// ListThemes returns the names of the themes in the themes directory next to config.toml
+func ListThemes() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Dir(themeFilePath("")))
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".toml"); ok && !entry.IsDir() {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// WatchedFiles returns the config file and the user theme file which the config refers to
+func (c *Config) WatchedFiles(darkBackground bool) []string {
+	files := []string{getConfigFilePath()}
+	if name := c.ThemeName(darkBackground); name != "" {
+		files = append(files, themeFilePath(name))
+	}
+	return files
+}
+
+// FileWatcher detects changes to a set of files by polling their modification times
+type FileWatcher struct {
+	modTimes map[string]time.Time
+}
+
+func NewFileWatcher(files []string) *FileWatcher {
+	w := &FileWatcher{modTimes: make(map[string]time.Time)}
+	for _, file := range files {
+		w.modTimes[file] = modTime(file)
+	}
+	return w
+}
+
+// Changed reports whether any of the files was modified, created or deleted since the last call
+func (w *FileWatcher) Changed() bool {
+	changed := false
+	for file, previous := range w.modTimes {
+		current := modTime(file)
+		if !current.Equal(previous) {
+			w.modTimes[file] = current
+			changed = true
+		}
+	}
+	return changed
+}
+
+func modTime(file string) time.Time {
+	if file == "" {
+		return time.Time{}
+	}
+	if info, err := os.Stat(file); err == nil {
+		return info.ModTime()
+	}
+	return time.Time{}
+}
+
+func themeFilePath(name string) string {
+	return filepath.Join(filepath.Dir(getConfigFilePath()), "themes", name+".toml")
+}
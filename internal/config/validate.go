@@ -0,0 +1,103 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// these tables are decoded by their own loaders
+var separatelyDecodedTables = []string{"custom_commands", "leader"}
+
+// these are only used while an operation or a popup is active, e.g. `apply` and `inline_describe.mode` are both `enter`
+var operationKeys = []string{"apply", "force_apply", "cancel"}
+
+// Validate reports the keys of config.toml which are not recognised and the key bindings that conflict within the same mode
+func Validate(data string) ([]string, error) {
+	var issues []string
+	c := loadDefaultConfig()
+	metadata, err := toml.Decode(data, c)
+	if err != nil {
+		return nil, err
+	}
+	for _, undecoded := range metadata.Undecoded() {
+		if len(undecoded) > 0 && slices.Contains(separatelyDecodedTables, undecoded[0]) {
+			continue
+		}
+		issues = append(issues, fmt.Sprintf("unknown key: %s", undecoded.String()))
+	}
+	issues = append(issues, c.Keys.conflicts()...)
+	issues = append(issues, c.Keys.chordOverlaps()...)
+	return issues, nil
+}
+
+// conflicts finds keys bound to more than one action of the same mode.
+// Top level actions and the `mode` keys of the nested tables are all available in the revisions view,
+// so they are checked together.
+func (k KeyMappings[T]) conflicts() []string {
+	modes := map[string]map[string][]string{}
+	var modeNames []string
+	add := func(mode string, action string, bound keys) {
+		if mode == "keys" && slices.Contains(operationKeys, action) {
+			return
+		}
+		if _, ok := modes[mode]; !ok {
+			modes[mode] = map[string][]string{}
+			modeNames = append(modeNames, mode)
+		}
+		for _, b := range slices.Compact(slices.Clone(bound)) {
+			modes[mode][b] = append(modes[mode][b], action)
+		}
+	}
+
+	k.walk(add)
+
+	var issues []string
+	for _, mode := range modeNames {
+		bindings := modes[mode]
+		boundKeys := make([]string, 0, len(bindings))
+		for b := range bindings {
+			boundKeys = append(boundKeys, b)
+		}
+		slices.Sort(boundKeys)
+		for _, b := range boundKeys {
+			if actions := bindings[b]; len(actions) > 1 {
+				issues = append(issues, fmt.Sprintf("%s: %q is bound to more than one action: %s", mode, b, strings.Join(actions, ", ")))
+			}
+		}
+	}
+	return issues
+}
+
+// walk visits the bindings of every action. Top level actions and the `mode` keys of the nested tables
+// are reported in the "keys" group, the other actions of a nested table in the "keys.<table>" group.
+func (k KeyMappings[T]) walk(visit func(group string, action string, bound keys)) {
+	v := reflect.ValueOf(k)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("toml")
+		field := v.Field(i)
+		if bound, ok := field.Interface().(keys); ok {
+			visit("keys", name, bound)
+			continue
+		}
+		if field.Kind() != reflect.Struct {
+			continue
+		}
+		for j := 0; j < field.NumField(); j++ {
+			action := field.Type().Field(j).Tag.Get("toml")
+			bound, ok := field.Field(j).Interface().(keys)
+			if !ok {
+				continue
+			}
+			if action == "mode" {
+				visit("keys", name+".mode", bound)
+				continue
+			}
+			visit("keys."+name, action, bound)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package jj
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const authorTemplate = `change_id.shortest() ++ "\t" ++ author.name() ++ "\t" ++ author.email() ++ "\t" ++ author.timestamp().format("%Y-%m-%d %H:%M:%S %:z") ++ "\n"`
+
+type Author struct {
+	ChangeId  string
+	Name      string
+	Email     string
+	Timestamp string
+}
+
+// ParseAuthorsOutput parses the output of GetAuthors
+func ParseAuthorsOutput(output string) []Author {
+	var authors []Author
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.Split(strings.TrimRight(line, "\r"), "\t")
+		if len(parts) != 4 {
+			continue
+		}
+		authors = append(authors, Author{ChangeId: parts[0], Name: parts[1], Email: parts[2], Timestamp: parts[3]})
+	}
+	return authors
+}
+
+var versionRegex = regexp.MustCompile(`(\d+)\.(\d+)`)
+
+// SupportsMetaedit reports whether the output of `jj --version` belongs to a jj release which has `jj metaedit`
+func SupportsMetaedit(versionOutput string) bool {
+	matches := versionRegex.FindStringSubmatch(versionOutput)
+	if matches == nil {
+		return false
+	}
+	major, _ := strconv.Atoi(matches[1])
+	minor, _ := strconv.Atoi(matches[2])
+	return major > 0 || minor >= 31
+}
@@ -25,6 +25,9 @@ const (
 	// the reason is user can use checked commits as revsets
 	// given to jj commands.
 	CheckedCommitIdsPlaceholder = "$checked_commit_ids"
+
+	// the text entered for the `prompt` of a custom command
+	InputPlaceholder = "$input"
 )
 
 type CommandArgs []string
@@ -33,6 +36,11 @@ func ConfigListAll() CommandArgs {
 	return []string{"config", "list", "--color", "never", "--include-defaults", "--ignore-working-copy"}
 }
 
+// ConfigListRepo lists the repository level config under the given name
+func ConfigListRepo(name string) CommandArgs {
+	return []string{"config", "list", "--repo", name, "--color", "never", "--ignore-working-copy"}
+}
+
 func Log(revset string, limit int) CommandArgs {
 	args := []string{"log", "--color", "always", "--quiet"}
 	if revset != "" {
@@ -47,14 +55,43 @@ func Log(revset string, limit int) CommandArgs {
 	return args
 }
 
-func New(revisions SelectedRevisions) CommandArgs {
+func NewWithParents(parents SelectedRevisions, message string) CommandArgs {
 	args := []string{"new"}
-	args = append(args, revisions.AsArgs()...)
+	args = append(args, parents.AsArgs()...)
+	if message != "" {
+		args = append(args, "-m", message)
+	}
+	return args
+}
+
+func NewInsert(insertAfter []string, insertBefore []string, message string) CommandArgs {
+	args := []string{"new"}
+	for _, revision := range insertAfter {
+		args = append(args, "--insert-after", revision)
+	}
+	for _, revision := range insertBefore {
+		args = append(args, "--insert-before", revision)
+	}
+	if message != "" {
+		args = append(args, "-m", message)
+	}
+	return args
+}
+
+func CommitWorkingCopy(files []string) CommandArgs {
+	args := []string{"commit"}
+	for _, file := range files {
+		args = append(args, EscapeFileName(file))
+	}
 	return args
 }
 
-func CommitWorkingCopy() CommandArgs {
-	return []string{"commit"}
+func CommitWithMessage(message string, files []string) CommandArgs {
+	args := []string{"commit", "-m", message}
+	for _, file := range files {
+		args = append(args, EscapeFileName(file))
+	}
+	return args
 }
 
 func Edit(changeId string, ignoreImmutable bool) CommandArgs {
@@ -309,6 +346,93 @@ func Duplicate(from SelectedRevisions, to string, target string) CommandArgs {
 	return args
 }
 
+func Fix(revisions SelectedRevisions) CommandArgs {
+	args := []string{"fix"}
+	args = append(args, revisions.AsPrefixedArgs("-s")...)
+	return args
+}
+
+func ConfigListFix() CommandArgs {
+	return []string{"config", "list", "fix", "--color", "never", "--ignore-working-copy"}
+}
+
+// GetChangeAndCommitIds lists `<change id> <commit id>` pairs, one per line, for the revisions in the revset
+func GetChangeAndCommitIds(revset string) CommandArgs {
+	return []string{"log", "-r", revset, "--color", "never", "--no-graph", "--quiet", "--ignore-working-copy", "--template", "change_id.shortest() ++ ' ' ++ commit_id ++ '\n'"}
+}
+
+// FilesMatching lists the files changed in the given revisions that match the fileset
+func FilesMatching(revisions SelectedRevisions, fileset string) CommandArgs {
+	template := fmt.Sprintf("diff(%s).files().map(|x| x.path() ++ '\n').join('')", quoteTemplateString(fileset))
+	args := []string{"log"}
+	args = append(args, revisions.AsArgs()...)
+	args = append(args, "--color", "never", "--no-graph", "--quiet", "--ignore-working-copy", "--template", template)
+	return args
+}
+
+func DiffSummary(from string, to string) CommandArgs {
+	return []string{"diff", "--from", from, "--to", to, "--summary", "--color", "never", "--ignore-working-copy"}
+}
+
+func DiffFromTo(from string, to string, fileName string) CommandArgs {
+	args := []string{"diff", "--from", from, "--to", to, "--color", "always", "--ignore-working-copy"}
+	if fileName != "" {
+		args = append(args, EscapeFileName(fileName))
+	}
+	return args
+}
+
+func Sign(revisions SelectedRevisions, ignoreImmutable bool) CommandArgs {
+	args := []string{"sign"}
+	args = append(args, revisions.AsArgs()...)
+	if ignoreImmutable {
+		args = append(args, "--ignore-immutable")
+	}
+	return args
+}
+
+func Unsign(revisions SelectedRevisions, ignoreImmutable bool) CommandArgs {
+	args := []string{"unsign"}
+	args = append(args, revisions.AsArgs()...)
+	if ignoreImmutable {
+		args = append(args, "--ignore-immutable")
+	}
+	return args
+}
+
+func GetSignatures(revset string) CommandArgs {
+	return []string{"log", "-r", revset, "--color", "never", "--no-graph", "--quiet", "--ignore-working-copy", "--template", signatureTemplate}
+}
+
+func Version() CommandArgs {
+	return []string{"--version"}
+}
+
+func GetAuthors(revset string) CommandArgs {
+	return []string{"log", "-r", revset, "--color", "never", "--no-graph", "--quiet", "--ignore-working-copy", "--template", authorTemplate}
+}
+
+// ResetAuthor resets the author of the revisions to the configured user.
+// `jj metaedit` replaces the `jj describe` flags starting from 0.31
+func ResetAuthor(revisions SelectedRevisions, useMetaedit bool) CommandArgs {
+	args := []string{"describe", "--no-edit", "--reset-author"}
+	if useMetaedit {
+		args = []string{"metaedit", "--update-author"}
+	}
+	args = append(args, revisions.AsArgs()...)
+	return args
+}
+
+func SetAuthor(revisions SelectedRevisions, name string, email string, useMetaedit bool) CommandArgs {
+	args := []string{"describe", "--no-edit"}
+	if useMetaedit {
+		args = []string{"metaedit"}
+	}
+	args = append(args, "--author", fmt.Sprintf("%s <%s>", name, email))
+	args = append(args, revisions.AsArgs()...)
+	return args
+}
+
 func Evolog(revision string) CommandArgs {
 	return []string{"evolog", "-r", revision, "--color", "always", "--quiet", "--ignore-working-copy"}
 }
@@ -317,6 +441,11 @@ func Args(args ...string) CommandArgs {
 	return args
 }
 
+// MatchingRevision lists the change id of the revision if it is in the revset, and nothing otherwise
+func MatchingRevision(changeId string, revset string) CommandArgs {
+	return []string{"log", "-r", fmt.Sprintf("%s & (%s)", changeId, revset), "--no-graph", "--color", "never", "--quiet", "--ignore-working-copy", "--template", "change_id.shortest()"}
+}
+
 func TemplatedArgs(templatedArgs []string, replacements map[string]string) CommandArgs {
 	var args []string
 	if fileReplacement, exists := replacements[FilePlaceholder]; exists {
@@ -394,6 +523,13 @@ func FilesInRevision(revision *Commit) CommandArgs {
 	return args
 }
 
+// TrackedFiles lists the files of the working copy as of the last snapshot, without the ignored files
+func TrackedFiles() CommandArgs {
+	return []string{"file", "list", "-r", "@",
+		"--color", "never", "--no-pager", "--quiet", "--ignore-working-copy",
+		"--template", "self.path() ++ \"\n\""}
+}
+
 func GetIdsFromRevset(revset string) CommandArgs {
 	return []string{"log", "-r", revset, "--color", "never", "--no-graph", "--quiet", "--ignore-working-copy", "--template", "change_id.shortest() ++ '\n'"}
 }
@@ -408,3 +544,10 @@ func EscapeFileName(fileName string) string {
 	}
 	return fmt.Sprintf("file:\"%s\"", fileName)
 }
+
+// quoteTemplateString turns s into a double-quoted jj template string literal
+func quoteTemplateString(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, "\"", "\\\"")
+	return "\"" + s + "\""
+}
@@ -0,0 +1,191 @@
+package jj
+
+import (
+	"bytes"
+	"hash/fnv"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RepoWatcher detects new operations and changes to the working copy by polling. An operation landing
+// replaces the files in `.jj/repo/op_heads/heads`, the working copy is checked by the modification times
+// and sizes of the files tracked by jj and of their directories, which change when a file is added. A
+// change is only reported once the repository has been quiet for the debounce period, so that a burst of
+// writes results in a single refresh.
+type RepoWatcher struct {
+	mu          sync.Mutex
+	opHeadsDir  string
+	workingCopy string
+	run         func(args []string) ([]byte, error)
+	maxFiles    int
+	debounce    time.Duration
+	// filesInterval is how often the working copy files are checked, the op heads are checked on every poll
+	filesInterval time.Duration
+	synced        bool
+	opHeads       uint64
+	// paths are the tracked files and their directories, nil when the working copy is not watched
+	paths        []string
+	tooLarge     bool
+	filesState   uint64
+	filesChecked time.Time
+	pending      bool
+	lastChange   time.Time
+}
+
+// NewRepoWatcher watches the repository at location, run runs jj to list the tracked files after each
+// operation. The working copy is not watched when maxFiles is 0, nor when it has more files than maxFiles.
+func NewRepoWatcher(location string, run func(args []string) ([]byte, error), maxFiles int, debounce time.Duration, filesInterval time.Duration) *RepoWatcher {
+	return &RepoWatcher{
+		opHeadsDir:    filepath.Join(repoDir(location), "op_heads", "heads"),
+		workingCopy:   location,
+		run:           run,
+		maxFiles:      maxFiles,
+		debounce:      debounce,
+		filesInterval: filesInterval,
+	}
+}
+
+// Poll reports whether the repository has changed and then stayed unchanged for the debounce period.
+// The first poll only records the state of the repository.
+func (w *RepoWatcher) Poll(now time.Time) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.synced {
+		w.sync(now)
+		return false
+	}
+	changed := false
+	if opHeads := w.readOpHeads(); opHeads != w.opHeads {
+		// the operation may have added or removed tracked files
+		w.opHeads = opHeads
+		w.loadPaths()
+		w.filesState = w.statPaths()
+		w.filesChecked = now
+		changed = true
+	} else if w.paths != nil && now.Sub(w.filesChecked) >= w.filesInterval {
+		w.filesChecked = now
+		if state := w.statPaths(); state != w.filesState {
+			w.filesState = state
+			changed = true
+		}
+	}
+	if changed {
+		w.pending = true
+		w.lastChange = now
+		return false
+	}
+	if w.pending && now.Sub(w.lastChange) >= w.debounce {
+		w.pending = false
+		return true
+	}
+	return false
+}
+
+// Sync records the current state of the repository as seen, e.g. after jjui has refreshed by itself,
+// so that the operations run by jjui do not cause another refresh
+func (w *RepoWatcher) Sync(now time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.sync(now)
+}
+
+func (w *RepoWatcher) sync(now time.Time) {
+	w.synced = true
+	w.pending = false
+	w.opHeads = w.readOpHeads()
+	w.loadPaths()
+	w.filesState = w.statPaths()
+	w.filesChecked = now
+}
+
+// repoDir returns the directory of the repository, `.jj/repo` is a file with the path of the repository
+// in secondary workspaces
+func repoDir(location string) string {
+	repo := filepath.Join(location, ".jj", "repo")
+	if info, err := os.Stat(repo); err == nil && !info.IsDir() {
+		if content, err := os.ReadFile(repo); err == nil {
+			path := strings.TrimSpace(string(content))
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(location, ".jj", path)
+			}
+			return path
+		}
+	}
+	return repo
+}
+
+// readOpHeads hashes the names of the op heads
+func (w *RepoWatcher) readOpHeads() uint64 {
+	h := fnv.New64a()
+	if entries, err := os.ReadDir(w.opHeadsDir); err == nil {
+		names := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			h.Write([]byte(name))
+			h.Write([]byte{0})
+		}
+	}
+	return h.Sum64()
+}
+
+// loadPaths lists the files tracked in the working copy, so that the ignored files are never looked at
+func (w *RepoWatcher) loadPaths() {
+	w.paths = nil
+	if w.maxFiles <= 0 || w.run == nil {
+		return
+	}
+	output, err := w.run(TrackedFiles())
+	if err != nil {
+		log.Println("repo watcher: cannot list the working copy files:", err)
+		return
+	}
+	files := bytes.Count(output, []byte{'\n'})
+	if files > w.maxFiles {
+		if !w.tooLarge {
+			log.Printf("repo watcher: the working copy has %d files which is more than max_files (%d), only new operations are watched", files, w.maxFiles)
+		}
+		w.tooLarge = true
+		return
+	}
+	w.tooLarge = false
+
+	dirs := map[string]bool{w.workingCopy: true}
+	for line := range strings.Lines(string(output)) {
+		path := strings.TrimSuffix(line, "\n")
+		if path == "" {
+			continue
+		}
+		path = filepath.Join(w.workingCopy, path)
+		w.paths = append(w.paths, path)
+		for dir := filepath.Dir(path); !dirs[dir] && strings.HasPrefix(dir, w.workingCopy); dir = filepath.Dir(dir) {
+			dirs[dir] = true
+		}
+	}
+	for dir := range dirs {
+		w.paths = append(w.paths, dir)
+	}
+	slices.Sort(w.paths)
+}
+
+// statPaths hashes the modification times and sizes of the watched paths
+func (w *RepoWatcher) statPaths() uint64 {
+	h := fnv.New64a()
+	for _, path := range w.paths {
+		h.Write([]byte(path))
+		if info, err := os.Lstat(path); err == nil {
+			h.Write([]byte(strconv.FormatInt(info.ModTime().UnixNano(), 36)))
+			h.Write([]byte(strconv.FormatInt(info.Size(), 36)))
+		}
+		h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
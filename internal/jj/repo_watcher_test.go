@@ -0,0 +1,135 @@
+package jj
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRepo(t *testing.T) (string, string) {
+	location := t.TempDir()
+	heads := filepath.Join(location, ".jj", "repo", "op_heads", "heads")
+	require.NoError(t, os.MkdirAll(heads, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(heads, "aaa"), nil, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(location, "file.txt"), []byte("one"), 0o644))
+	return location, heads
+}
+
+func TestRepoWatcher_ReportsNewOperationsOnceSettled(t *testing.T) {
+	location, heads := newRepo(t)
+	watcher := NewRepoWatcher(location, nil, 0, time.Second, 0)
+	now := time.Now()
+	assert.False(t, watcher.Poll(now))
+
+	require.NoError(t, os.Rename(filepath.Join(heads, "aaa"), filepath.Join(heads, "bbb")))
+	assert.False(t, watcher.Poll(now), "expected the change to wait for the debounce period")
+	assert.False(t, watcher.Poll(now.Add(500*time.Millisecond)))
+	assert.True(t, watcher.Poll(now.Add(time.Second)))
+	assert.False(t, watcher.Poll(now.Add(2*time.Second)), "expected a change to be reported once")
+
+	require.NoError(t, os.WriteFile(filepath.Join(location, "file.txt"), []byte("two"), 0o644))
+	assert.False(t, watcher.Poll(now.Add(3*time.Second)))
+	assert.False(t, watcher.Poll(now.Add(5*time.Second)), "expected the working copy not to be watched")
+}
+
+// trackedFiles runs `jj file list` with the given output
+func trackedFiles(t *testing.T, output string) func(args []string) ([]byte, error) {
+	return func(args []string) ([]byte, error) {
+		assert.Equal(t, []string(TrackedFiles()), args)
+		return []byte(output), nil
+	}
+}
+
+func TestRepoWatcher_WatchesWorkingCopyFiles(t *testing.T) {
+	location, _ := newRepo(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(location, "target"), 0o755))
+	watcher := NewRepoWatcher(location, trackedFiles(t, "file.txt\n"), 10, 0, 0)
+	now := time.Now()
+	assert.False(t, watcher.Poll(now))
+
+	require.NoError(t, os.WriteFile(filepath.Join(location, "file.txt"), []byte("changed"), 0o644))
+	assert.False(t, watcher.Poll(now))
+	assert.True(t, watcher.Poll(now))
+
+	require.NoError(t, os.WriteFile(filepath.Join(location, ".jj", "repo", "index"), []byte("ignored"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(location, "target", "build.log"), []byte("ignored"), 0o644))
+	assert.False(t, watcher.Poll(now))
+	assert.False(t, watcher.Poll(now), "expected the files which are not tracked not to count as working copy changes")
+
+	require.NoError(t, os.WriteFile(filepath.Join(location, "new.txt"), []byte("new"), 0o644))
+	assert.False(t, watcher.Poll(now))
+	assert.True(t, watcher.Poll(now), "expected a new file to change its directory")
+}
+
+func TestRepoWatcher_ChecksFilesAtTheirOwnInterval(t *testing.T) {
+	location, _ := newRepo(t)
+	watcher := NewRepoWatcher(location, trackedFiles(t, "file.txt\n"), 10, 0, 2*time.Second)
+	now := time.Now()
+	assert.False(t, watcher.Poll(now))
+
+	require.NoError(t, os.WriteFile(filepath.Join(location, "file.txt"), []byte("changed"), 0o644))
+	assert.False(t, watcher.Poll(now.Add(time.Second)))
+	assert.False(t, watcher.Poll(now.Add(time.Second)), "expected the files not to be checked before the interval")
+	assert.False(t, watcher.Poll(now.Add(2*time.Second)))
+	assert.True(t, watcher.Poll(now.Add(2*time.Second)))
+}
+
+func TestRepoWatcher_ListsFilesOncePerOperation(t *testing.T) {
+	location, heads := newRepo(t)
+	listed := 0
+	watcher := NewRepoWatcher(location, func([]string) ([]byte, error) {
+		listed++
+		return []byte("file.txt\n"), nil
+	}, 10, 0, 0)
+	now := time.Now()
+	watcher.Poll(now)
+	watcher.Poll(now)
+	watcher.Poll(now)
+	assert.Equal(t, 1, listed)
+
+	require.NoError(t, os.WriteFile(filepath.Join(heads, "bbb"), nil, 0o644))
+	watcher.Poll(now)
+	watcher.Poll(now)
+	assert.Equal(t, 2, listed)
+}
+
+func TestRepoWatcher_SkipsWorkingCopyWithTooManyFiles(t *testing.T) {
+	location, _ := newRepo(t)
+	watcher := NewRepoWatcher(location, trackedFiles(t, "file.txt\na.txt\nb.txt\n"), 2, 0, 0)
+	now := time.Now()
+	assert.False(t, watcher.Poll(now))
+
+	require.NoError(t, os.WriteFile(filepath.Join(location, "file.txt"), []byte("changed"), 0o644))
+	assert.False(t, watcher.Poll(now))
+	assert.False(t, watcher.Poll(now), "expected only new operations to be watched")
+}
+
+func TestRepoWatcher_SyncSkipsOwnOperations(t *testing.T) {
+	location, heads := newRepo(t)
+	watcher := NewRepoWatcher(location, nil, 0, 0, 0)
+	now := time.Now()
+	assert.False(t, watcher.Poll(now))
+
+	require.NoError(t, os.WriteFile(filepath.Join(heads, "bbb"), nil, 0o644))
+	watcher.Sync(now)
+	assert.False(t, watcher.Poll(now))
+	assert.False(t, watcher.Poll(now), "expected the synced operation not to be reported")
+}
+
+func TestRepoWatcher_FollowsSecondaryWorkspaces(t *testing.T) {
+	main, heads := newRepo(t)
+	workspace := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(workspace, ".jj"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(workspace, ".jj", "repo"), []byte(filepath.Join(main, ".jj", "repo")), 0o644))
+
+	watcher := NewRepoWatcher(workspace, nil, 0, 0, 0)
+	now := time.Now()
+	assert.False(t, watcher.Poll(now))
+	require.NoError(t, os.WriteFile(filepath.Join(heads, "ccc"), nil, 0o644))
+	assert.False(t, watcher.Poll(now))
+	assert.True(t, watcher.Poll(now))
+}
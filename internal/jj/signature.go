@@ -0,0 +1,58 @@
+package jj
+
+import (
+	"slices"
+	"strings"
+)
+
+type SignatureStatus string
+
+const (
+	SignatureNone    SignatureStatus = ""
+	SignatureGood    SignatureStatus = "good"
+	SignatureBad     SignatureStatus = "bad"
+	SignatureUnknown SignatureStatus = "unknown"
+	SignatureInvalid SignatureStatus = "invalid"
+)
+
+const signatureTemplate = `commit_id ++ " " ++ if(signature, signature.status(), "none") ++ "\n"`
+
+// ParseSignaturesOutput parses the output of GetSignatures into a commit id to signature status map
+func ParseSignaturesOutput(output string) map[string]SignatureStatus {
+	ret := make(map[string]SignatureStatus)
+	for _, line := range strings.Split(output, "\n") {
+		commitId, status, found := strings.Cut(strings.TrimSpace(line), " ")
+		if !found {
+			continue
+		}
+		switch SignatureStatus(status) {
+		case SignatureGood, SignatureBad, SignatureUnknown, SignatureInvalid:
+			ret[commitId] = SignatureStatus(status)
+		default:
+			ret[commitId] = SignatureNone
+		}
+	}
+	return ret
+}
+
+// MatchSignatures keys the signatures, which are parsed with full commit ids, by the shortened commit ids
+// shown in the log
+func MatchSignatures(commitIds []string, signatures map[string]SignatureStatus) map[string]SignatureStatus {
+	requested := make(map[string]bool, len(commitIds))
+	var lengths []int
+	for _, commitId := range commitIds {
+		requested[commitId] = true
+		if !slices.Contains(lengths, len(commitId)) {
+			lengths = append(lengths, len(commitId))
+		}
+	}
+	ret := make(map[string]SignatureStatus, len(commitIds))
+	for fullId, status := range signatures {
+		for _, length := range lengths {
+			if length <= len(fullId) && requested[fullId[:length]] {
+				ret[fullId[:length]] = status
+			}
+		}
+	}
+	return ret
+}
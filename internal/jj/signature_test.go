@@ -0,0 +1,33 @@
+package jj
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSignaturesOutput(t *testing.T) {
+	output := `aaaa good
+bbbb bad
+cccc unknown
+dddd none
+eeee invalid
+`
+	signatures := ParseSignaturesOutput(output)
+	assert.Len(t, signatures, 5)
+	assert.Equal(t, SignatureGood, signatures["aaaa"])
+	assert.Equal(t, SignatureBad, signatures["bbbb"])
+	assert.Equal(t, SignatureUnknown, signatures["cccc"])
+	assert.Equal(t, SignatureNone, signatures["dddd"])
+	assert.Equal(t, SignatureInvalid, signatures["eeee"])
+}
+
+func TestMatchSignatures(t *testing.T) {
+	signatures := map[string]SignatureStatus{
+		"aaaa1111": SignatureGood,
+		"bbbb2222": SignatureBad,
+		"cccc3333": SignatureNone,
+	}
+	matched := MatchSignatures([]string{"aaaa", "bbb", "dddd"}, signatures)
+	assert.Equal(t, map[string]SignatureStatus{"aaaa": SignatureGood, "bbb": SignatureBad}, matched)
+}
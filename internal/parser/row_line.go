@@ -2,6 +2,7 @@ package parser
 
 import (
 	"strings"
+	"unicode/utf8"
 
 	"github.com/idursun/jjui/internal/screen"
 )
@@ -38,50 +39,34 @@ func (gr *GraphRowLine) FindPossibleCommitIdIdx(after int) int {
 	return -1
 }
 
+// chop moves the first indent runes of the line to the gutter, with a segment per rune. The gutter segments
+// of a line are allocated together since a large log has millions of them.
 func (gr *GraphRowLine) chop(indent int) {
 	if len(gr.Segments) == 0 {
 		return
 	}
 	segments := gr.Segments
-	gr.Segments = make([]*screen.Segment, 0)
-
+	gutter := make([]screen.Segment, 0, indent)
+	var rest []*screen.Segment
 	for i, s := range segments {
-		extended := screen.Segment{
-			Style: s.Style,
-		}
-		var textBuilder strings.Builder
-		for _, p := range s.Text {
+		for at, r := range s.Text {
 			if indent <= 0 {
+				rest = append(rest, &screen.Segment{Text: s.Text[at:], Style: s.Style})
 				break
 			}
-			textBuilder.WriteRune(p)
+			gutter = append(gutter, screen.Segment{Text: s.Text[at : at+utf8.RuneLen(r)], Style: s.Style})
 			indent--
 		}
-		extended.Text = textBuilder.String()
-		gr.Gutter.Segments = append(gr.Gutter.Segments, &extended)
-		if len(extended.Text) < len(s.Text) {
-			gr.Segments = append(gr.Segments, &screen.Segment{
-				Text:  s.Text[len(extended.Text):],
-				Style: s.Style,
-			})
-		}
-		if indent <= 0 && len(segments)-i-1 > 0 {
-			gr.Segments = segments[i+1:]
+		if indent <= 0 {
+			rest = append(rest, segments[i+1:]...)
 			break
 		}
 	}
+	gr.Segments = rest
 
-	// break gutter into segments per rune
-	segments = gr.Gutter.Segments
-	gr.Gutter.Segments = make([]*screen.Segment, 0)
-	for _, s := range segments {
-		for _, p := range s.Text {
-			extended := screen.Segment{
-				Text:  string(p),
-				Style: s.Style,
-			}
-			gr.Gutter.Segments = append(gr.Gutter.Segments, &extended)
-		}
+	gr.Gutter.Segments = make([]*screen.Segment, len(gutter))
+	for i := range gutter {
+		gr.Gutter.Segments[i] = &gutter[i]
 	}
 
 	// Pad with spaces if indent is not fully consumed
@@ -89,7 +74,6 @@ func (gr *GraphRowLine) chop(indent int) {
 		lastSegment := gr.Gutter.Segments[len(gr.Gutter.Segments)-1]
 		lastSegment.Text += strings.Repeat(" ", indent)
 	}
-
 }
 
 func (gr *GraphRowLine) containsRune(r rune) bool {
@@ -50,3 +50,40 @@ func TestParseRowsStreaming_Close(t *testing.T) {
 	_, received := <-receiver
 	assert.False(t, received, "expected channel to be closed")
 }
+
+func parseAll(t testing.TB, log string, batchSize int) []Row {
+	controlChannel := make(chan ControlMsg)
+	receiver, err := ParseRowsStreaming(strings.NewReader(log), controlChannel, batchSize)
+	assert.NoError(t, err)
+	var rows []Row
+	for {
+		controlChannel <- RequestMore
+		batch := <-receiver
+		rows = append(rows, batch.Rows...)
+		if !batch.HasMore {
+			break
+		}
+	}
+	controlChannel <- Close
+	return rows
+}
+
+func TestParseRowsStreaming_SyntheticLog(t *testing.T) {
+	rows := parseAll(t, test.SyntheticLog(100), 50)
+	assert.Len(t, rows, 101)
+	assert.True(t, rows[0].Commit.IsWorkingCopy)
+	assert.Equal(t, "zzzzzzzz", rows[0].Commit.ChangeId)
+	assert.Equal(t, "zzzzzzzy", rows[1].Commit.ChangeId)
+	assert.Equal(t, "00001eef", rows[1].Commit.CommitId)
+	assert.Equal(t, 3, rows[4].Indent)
+	assert.Equal(t, 5, rows[5].Indent, "expected the side branch to be indented")
+}
+
+func BenchmarkParseRowsStreaming(b *testing.B) {
+	log := test.SyntheticLog(100_000)
+	b.SetBytes(int64(len(log)))
+	b.ReportAllocs()
+	for b.Loop() {
+		parseAll(b, log, 1000)
+	}
+}
@@ -30,6 +30,8 @@ func (n NoopTracer) UpdateGutterText(_ int, _ int, _ int, text string) string {
 
 type Tracer struct {
 	rows                 []Row
+	start                int
+	end                  int
 	nextLaneId           uint64
 	highlightedRowLane   uint64
 	highlightedLowestBit uint64
@@ -38,17 +40,36 @@ type Tracer struct {
 func NewTracer(rows []Row, cursor int, start int, end int) LaneTracer {
 	t := &Tracer{
 		rows:       rows,
+		start:      start,
+		end:        end,
 		nextLaneId: 0,
 	}
 	log.Println("Tracing lanes from", start, "to", end)
 	t.traceLanes(start, end)
+	t.highlight(cursor)
+	return t
+}
+
+// UpdateTracer returns the tracer for the cursor. The lanes traced by previous are reused when it has traced
+// the same range of the same rows, which is the case while the cursor moves within the view.
+func UpdateTracer(previous LaneTracer, rows []Row, cursor int, start int, end int) LaneTracer {
+	if t, ok := previous.(*Tracer); ok && t.start == start && t.end == end && sameRows(t.rows, rows) {
+		t.highlight(cursor)
+		return t
+	}
+	return NewTracer(rows, cursor, start, end)
+}
 
+func sameRows(a []Row, b []Row) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
+
+func (t *Tracer) highlight(cursor int) {
+	t.highlightedRowLane, t.highlightedLowestBit = 0, 0
 	if cursor >= 0 && cursor < len(t.rows) {
 		t.highlightedRowLane = t.getRowLane(cursor)
 		t.highlightedLowestBit = t.highlightedRowLane & -t.highlightedRowLane
 	}
-
-	return t
 }
 
 func (t *Tracer) IsInSameLane(current int) bool {
@@ -2,12 +2,14 @@ package parser
 
 import (
 	"bufio"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/idursun/jjui/internal/screen"
+	"github.com/idursun/jjui/test"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -92,9 +94,9 @@ func TestTracer_IsGutterInLane(t *testing.T) {
 		&GraphRowLine{
 			Gutter: GraphGutter{
 				Segments: []*screen.Segment{
-					{Text: "│", Style: lipgloss.NewStyle()},
-					{Text: " ", Style: lipgloss.NewStyle()},
-					{Text: "◆", Style: lipgloss.NewStyle()},
+					{Text: "│", Style: stylePtr(lipgloss.NewStyle())},
+					{Text: " ", Style: stylePtr(lipgloss.NewStyle())},
+					{Text: "◆", Style: stylePtr(lipgloss.NewStyle())},
 				},
 			},
 			Flags: Revision | Highlightable,
@@ -102,27 +104,27 @@ func TestTracer_IsGutterInLane(t *testing.T) {
 		&GraphRowLine{
 			Gutter: GraphGutter{
 				Segments: []*screen.Segment{
-					{Text: "│", Style: lipgloss.NewStyle()},
-					{Text: " ", Style: lipgloss.NewStyle()},
-					{Text: "│", Style: lipgloss.NewStyle()},
+					{Text: "│", Style: stylePtr(lipgloss.NewStyle())},
+					{Text: " ", Style: stylePtr(lipgloss.NewStyle())},
+					{Text: "│", Style: stylePtr(lipgloss.NewStyle())},
 				},
 			},
 		},
 		&GraphRowLine{
 			Gutter: GraphGutter{
 				Segments: []*screen.Segment{
-					{Text: "│", Style: lipgloss.NewStyle()},
-					{Text: " ", Style: lipgloss.NewStyle()},
-					{Text: "~", Style: lipgloss.NewStyle()},
+					{Text: "│", Style: stylePtr(lipgloss.NewStyle())},
+					{Text: " ", Style: stylePtr(lipgloss.NewStyle())},
+					{Text: "~", Style: stylePtr(lipgloss.NewStyle())},
 				},
 			},
 		},
 		&GraphRowLine{
 			Gutter: GraphGutter{
 				Segments: []*screen.Segment{
-					{Text: "├", Style: lipgloss.NewStyle()},
-					{Text: "─", Style: lipgloss.NewStyle()},
-					{Text: "╯", Style: lipgloss.NewStyle()},
+					{Text: "├", Style: stylePtr(lipgloss.NewStyle())},
+					{Text: "─", Style: stylePtr(lipgloss.NewStyle())},
+					{Text: "╯", Style: stylePtr(lipgloss.NewStyle())},
 				},
 			},
 		})
@@ -166,7 +168,7 @@ func newTestTraceableRow(lines []string) Row {
 		for _, r := range line {
 			segments = append(segments, &screen.Segment{
 				Text:  string(r),
-				Style: lipgloss.NewStyle(),
+				Style: stylePtr(lipgloss.NewStyle()),
 			})
 		}
 		gutter := GraphGutter{
@@ -200,3 +202,34 @@ func createRows(g string) []Row {
 	}
 	return ret
 }
+
+func TestUpdateTracer_ReusesLanes(t *testing.T) {
+	rows := createRows(`
+○
+│
+│ ○
+├─╯
+○
+│
+`)
+	tracer := NewTracer(rows, 0, 0, len(rows))
+	assert.Same(t, tracer, UpdateTracer(tracer, rows, 2, 0, len(rows)), "expected the lanes to be reused")
+	assert.True(t, tracer.IsInSameLane(2))
+	assert.False(t, tracer.IsInSameLane(1))
+
+	assert.NotSame(t, tracer, UpdateTracer(tracer, rows, 2, 1, len(rows)), "expected a different range to be traced again")
+	assert.NotSame(t, tracer, UpdateTracer(tracer, slices.Clone(rows), 2, 0, len(rows)), "expected other rows to be traced again")
+}
+
+func BenchmarkNewTracer(b *testing.B) {
+	rows := parseAll(b, test.SyntheticLog(100_000), 1000)
+	b.ReportAllocs()
+	for b.Loop() {
+		// a screenful of rows in the middle of the log
+		NewTracer(rows, 50_010, 50_000, 50_030)
+	}
+}
+
+func stylePtr(style lipgloss.Style) *lipgloss.Style {
+	return &style
+}
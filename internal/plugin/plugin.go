@@ -0,0 +1,190 @@
+// Package plugin implements the protocol jjui uses to talk to external plugins.
+//
+// A plugin is an executable that is started for every request. jjui writes the request as a single line of
+// JSON to its stdin and closes it, the plugin replies with one action per line on its stdout and exits.
+// A non-zero exit code fails the request and its stderr is shown to the user. A plugin which runs longer
+// than `ui.plugin_timeout` is killed.
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ProtocolVersion is sent with every request so that plugins can detect changes to the protocol
+const ProtocolVersion = 1
+
+// Events a request is sent for
+const (
+	// EventInvoke is sent when the user runs the plugin
+	EventInvoke = "invoke"
+	// EventMenuSelect is sent when the user picks an item of a menu the plugin has shown
+	EventMenuSelect = "menu_select"
+)
+
+// Actions a plugin can reply with
+const (
+	// ActionRun runs `jj <args>` and refreshes the revisions afterward
+	ActionRun = "run"
+	// ActionSetRevset changes the revset of the revisions view
+	ActionSetRevset = "set_revset"
+	// ActionFlash shows a message in the status bar, as an error if `error` is set
+	ActionFlash = "flash"
+	// ActionShowDiff shows the text in the diff viewer
+	ActionShowDiff = "show_diff"
+	// ActionMenu shows a menu, picking one of its items sends a menu_select request with the item's id
+	ActionMenu = "menu"
+	// ActionInvoke runs a named jjui action like `git.push` with `args` as its arguments
+	ActionInvoke = "invoke"
+)
+
+var knownActions = []string{ActionRun, ActionSetRevset, ActionFlash, ActionShowDiff, ActionMenu, ActionInvoke}
+
+// Item is a revision, a file or an operation of the jjui views
+type Item struct {
+	Type        string `json:"type"`
+	ChangeId    string `json:"change_id,omitempty"`
+	CommitId    string `json:"commit_id,omitempty"`
+	File        string `json:"file,omitempty"`
+	OperationId string `json:"operation_id,omitempty"`
+}
+
+// Item types
+const (
+	ItemRevision  = "revision"
+	ItemFile      = "file"
+	ItemOperation = "operation"
+)
+
+// Context is the state of jjui at the time of the request
+type Context struct {
+	Location string `json:"location"`
+	Revset   string `json:"revset"`
+	Selected *Item  `json:"selected,omitempty"`
+	Checked  []Item `json:"checked"`
+	// Replacements are the values of the custom command placeholders, e.g. "$change_id"
+	Replacements map[string]string `json:"replacements"`
+}
+
+type Request struct {
+	Version int    `json:"version"`
+	Event   string `json:"event"`
+	// MenuItem is the id of the picked menu item of a menu_select request
+	MenuItem string  `json:"menu_item,omitempty"`
+	Context  Context `json:"context"`
+}
+
+type Action struct {
+	Action  string     `json:"action"`
+	Name    string     `json:"name,omitempty"`
+	Args    []string   `json:"args,omitempty"`
+	Revset  string     `json:"revset,omitempty"`
+	Message string     `json:"message,omitempty"`
+	Error   bool       `json:"error,omitempty"`
+	Text    string     `json:"text,omitempty"`
+	Title   string     `json:"title,omitempty"`
+	Items   []MenuItem `json:"items,omitempty"`
+}
+
+type MenuItem struct {
+	Id          string `json:"id"`
+	Label       string `json:"label"`
+	Description string `json:"description,omitempty"`
+	Key         string `json:"key,omitempty"`
+}
+
+// Invoke starts the plugin in dir, sends it the request and returns the actions it replied with. The plugin
+// is killed when ctx is done.
+func Invoke(ctx context.Context, command []string, dir string, request Request) ([]Action, error) {
+	if len(command) == 0 {
+		return nil, errors.New("plugin command is empty")
+	}
+	request.Version = ProtocolVersion
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	// the processes started by the plugin may keep its output open after it is killed
+	cmd.WaitDelay = time.Second
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), fmt.Sprintf("JJUI_PLUGIN_PROTOCOL=%d", ProtocolVersion))
+	cmd.Stdin = bytes.NewReader(append(input, '\n'))
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("%s was stopped: %w", command[0], ctx.Err())
+		}
+		var exitError *exec.ExitError
+		if errors.As(err, &exitError) {
+			err = fmt.Errorf("%s exited with %d", command[0], exitError.ExitCode())
+		}
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			err = fmt.Errorf("%w: %s", err, message)
+		}
+		return nil, err
+	}
+	return ParseActions(&stdout)
+}
+
+// ParseActions reads one action per line, empty lines are skipped
+func ParseActions(r io.Reader) ([]Action, error) {
+	var actions []Action
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var action Action
+		if err := json.Unmarshal(text, &action); err != nil {
+			return nil, fmt.Errorf("plugin reply line %d: %w", line, err)
+		}
+		if err := action.validate(); err != nil {
+			return nil, fmt.Errorf("plugin reply line %d: %w", line, err)
+		}
+		actions = append(actions, action)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
+
+func (a Action) validate() error {
+	switch a.Action {
+	case ActionRun:
+		if len(a.Args) == 0 {
+			return errors.New("run needs args")
+		}
+	case ActionMenu:
+		for _, item := range a.Items {
+			if item.Id == "" {
+				return errors.New("menu items need an id")
+			}
+		}
+	case ActionInvoke:
+		if a.Name == "" {
+			return errors.New("invoke needs the name of an action")
+		}
+	case ActionSetRevset, ActionFlash, ActionShowDiff:
+	default:
+		return fmt.Errorf("unknown action %q, expected one of %s", a.Action, strings.Join(knownActions, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package plugin
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseActions(t *testing.T) {
+	actions, err := ParseActions(strings.NewReader(`{"action": "run", "args": ["new"]}
+
+{"action": "flash", "message": "done", "error": true}
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []Action{
+		{Action: ActionRun, Args: []string{"new"}},
+		{Action: ActionFlash, Message: "done", Error: true},
+	}, actions)
+}
+
+func TestParseActions_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		reply string
+		err   string
+	}{
+		{name: "not json", reply: "hello", err: "plugin reply line 1"},
+		{name: "unknown action", reply: `{"action": "explode"}`, err: `unknown action "explode"`},
+		{name: "run without args", reply: `{"action": "flash"}` + "\n" + `{"action": "run"}`, err: "plugin reply line 2: run needs args"},
+		{name: "menu item without id", reply: `{"action": "menu", "items": [{"label": "x"}]}`, err: "menu items need an id"},
+		{name: "invoke without name", reply: `{"action": "invoke"}`, err: "invoke needs the name of an action"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseActions(strings.NewReader(tt.reply))
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	actions, err := Invoke(context.Background(), []string{"sh", "-c", `grep -q '"event":"invoke"' && echo '{"action": "set_revset", "revset": "@"}'`}, t.TempDir(), Request{Event: EventInvoke})
+	require.NoError(t, err)
+	assert.Equal(t, []Action{{Action: ActionSetRevset, Revset: "@"}}, actions)
+
+	_, err = Invoke(context.Background(), nil, t.TempDir(), Request{})
+	assert.Error(t, err)
+}
+
+func TestInvoke_StopsWhenTheContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := Invoke(ctx, []string{"sh", "-c", "sleep 30 | cat"}, t.TempDir(), Request{Event: EventInvoke})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 10*time.Second)
+}
@@ -19,66 +19,97 @@ func Parse(raw []byte) []Segment {
 	return segments
 }
 
+// maxInternedStyles bounds the styles a parse keeps, the styles after that are not shared
+const maxInternedStyles = 1024
+
+// styleInterner makes the segments with the same escape sequences share their style, a style is
+// kept for each style and escape sequence it was derived from
+type styleInterner struct {
+	plain   *lipgloss.Style
+	derived map[*lipgloss.Style]map[string]*lipgloss.Style
+	count   int
+}
+
+func newStyleInterner() *styleInterner {
+	plain := lipgloss.NewStyle()
+	return &styleInterner{
+		plain:   &plain,
+		derived: make(map[*lipgloss.Style]map[string]*lipgloss.Style),
+	}
+}
+
+func (i *styleInterner) apply(current *lipgloss.Style, params []byte) *lipgloss.Style {
+	if string(params) == "0" {
+		return i.plain
+	}
+	if style, ok := i.derived[current][string(params)]; ok {
+		return style
+	}
+	paramStr := string(params)
+	style := applyParamsToStyle(*current, paramStr)
+	if i.count < maxInternedStyles {
+		if i.derived[current] == nil {
+			i.derived[current] = make(map[string]*lipgloss.Style)
+		}
+		i.derived[current][paramStr] = &style
+		i.count++
+	}
+	return &style
+}
+
 func ParseFromReader(r io.Reader) <-chan *Segment {
-	ch := make(chan *Segment)
+	ch := make(chan *Segment, 64)
 	go func() {
 		defer close(ch)
 		var buffer bytes.Buffer
-		currentStyle := lipgloss.NewStyle()
+		var seq []byte
+		styles := newStyleInterner()
+		currentStyle := styles.plain
 		reader := bufio.NewReader(r)
 
 		for {
-			b, err := reader.ReadByte()
-			if err == io.EOF {
+			text, err := reader.ReadSlice(0x1B)
+			if err == bufio.ErrBufferFull {
+				buffer.Write(text)
+				continue
+			}
+			if err != nil {
+				buffer.Write(text)
 				break
 			}
+			buffer.Write(text[:len(text)-1])
+
+			peekBytes, err := reader.Peek(1)
 			if err != nil {
+				buffer.WriteByte(0x1B)
 				break
 			}
 
-			if b == 0x1B {
-				peekBytes, err := reader.Peek(1)
-				if err != nil {
-					buffer.WriteByte(b)
-					break
+			if peekBytes[0] != '[' {
+				nextByte, _ := reader.ReadByte()
+				buffer.WriteByte(0x1B)
+				buffer.WriteByte(nextByte)
+				continue
+			}
+
+			_, _ = reader.Discard(1)
+			if buffer.Len() > 0 {
+				ch <- &Segment{
+					Text:  buffer.String(),
+					Style: currentStyle,
 				}
+				buffer.Reset()
+			}
 
-				if len(peekBytes) >= 1 && peekBytes[0] == '[' {
-					_, _ = reader.Discard(1)
-					if buffer.Len() > 0 {
-						text := buffer.String()
-						ch <- &Segment{
-							Text:  text,
-							Style: currentStyle,
-						}
-						buffer.Reset()
-					}
-
-					var seq bytes.Buffer
-					for {
-						c, err := reader.ReadByte()
-						if err != nil || c == 'm' {
-							break
-						}
-						seq.WriteByte(c)
-					}
-
-					paramStr := seq.String()
-					if paramStr == "0" {
-						currentStyle = lipgloss.NewStyle()
-					} else {
-						currentStyle = applyParamsToStyle(currentStyle, paramStr)
-					}
-				} else {
-					buffer.WriteByte(b)
-					if len(peekBytes) >= 1 {
-						nextByte, _ := reader.ReadByte()
-						buffer.WriteByte(nextByte)
-					}
+			seq = seq[:0]
+			for {
+				c, err := reader.ReadByte()
+				if err != nil || c == 'm' {
+					break
 				}
-			} else {
-				buffer.WriteByte(b)
+				seq = append(seq, c)
 			}
+			currentStyle = styles.apply(currentStyle, seq)
 		}
 
 		if buffer.Len() > 0 {
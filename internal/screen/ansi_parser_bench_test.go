@@ -0,0 +1,19 @@
+package screen_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/idursun/jjui/internal/screen"
+	"github.com/idursun/jjui/test"
+)
+
+func BenchmarkParseFromReader(b *testing.B) {
+	log := test.SyntheticLog(100_000)
+	b.SetBytes(int64(len(log)))
+	b.ReportAllocs()
+	for b.Loop() {
+		for range screen.ParseFromReader(strings.NewReader(log)) {
+		}
+	}
+}
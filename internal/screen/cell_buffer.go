@@ -1,33 +1,30 @@
 package screen
 
 import (
-	"log"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/rivo/uniseg"
 )
 
-type cellBuffer struct {
-	grid [][]gridCell
-}
-
 type gridCell struct {
 	Segment
 	width int
 }
 
+var plainStyle = lipgloss.NewStyle()
+
 var emptyCell = gridCell{
 	Segment: Segment{
 		Text:  "",
-		Style: lipgloss.NewStyle(),
+		Style: &plainStyle,
 	},
 	width: 0,
 }
 var spaceCell = gridCell{
 	Segment: Segment{
 		Text:  " ",
-		Style: lipgloss.NewStyle(),
+		Style: &plainStyle,
 	},
 	width: 1,
 }
@@ -36,139 +33,81 @@ const TransparentFg = lipgloss.Color("#010203")
 const TransparentBg = lipgloss.Color("#010203")
 
 func Stacked(view1, view2 string, x, y int) string {
-	if x < 0 {
-		x = 0
-	}
-	if y < 0 {
-		y = 0
-	}
-	buf := &cellBuffer{}
-
-	buf.applyANSI([]byte(view1), 0, 0)
-	buf.applyANSI([]byte(view2), x, y)
-
-	return buf.String()
-}
-
-func (b *cellBuffer) applyANSI(input []byte, offsetX, offsetY int) {
-	for len(b.grid) <= offsetY {
-		b.grid = append(b.grid, []gridCell{})
-	}
-
-	if offsetX == 0 && offsetY == 0 && len(b.grid) == 0 {
-		b.grid = [][]gridCell{{}}
-		b.merge(input, 0, 0)
-	} else {
-		b.merge(input, offsetX, offsetY)
-	}
+	return NewCompositor().Compose(view1, Layer{Content: view2, X: x, Y: y})
 }
 
-func (b *cellBuffer) merge(input []byte, offsetX, offsetY int) {
-	parsed := Parse(input)
-
-	currentLine := offsetY
-	currentCol := offsetX
-
-	for len(b.grid) <= currentLine {
-		b.grid = append(b.grid, []gridCell{})
-	}
-
-	for _, st := range parsed {
-		gr := uniseg.NewGraphemes(st.Text)
+// parseCells splits the input into lines of grapheme clusters
+func parseCells(input string) [][]gridCell {
+	lines := [][]gridCell{{}}
+	for segment := range ParseFromReader(strings.NewReader(input)) {
+		gr := uniseg.NewGraphemes(segment.Text)
 		for gr.Next() {
 			cluster := gr.Str()
 			if cluster == "\n" {
-				currentLine++
-				currentCol = offsetX
-
-				for len(b.grid) <= currentLine {
-					b.grid = append(b.grid, []gridCell{})
-				}
+				lines = append(lines, []gridCell{})
 				continue
 			}
+			last := len(lines) - 1
+			lines[last] = append(lines[last], gridCell{
+				Segment: Segment{Text: cluster, Style: segment.Style},
+				width:   gr.Width(),
+			})
+		}
+	}
+	return lines
+}
 
-			if currentCol < 0 || currentLine < 0 {
-				log.Fatalf("line: %d, col: %d", currentLine, currentCol)
-			}
-
-			charWidth := gr.Width()
-
-			for len(b.grid[currentLine]) <= currentCol+charWidth-1 {
-				b.grid[currentLine] = append(b.grid[currentLine], spaceCell)
-			}
-
-			if currentCol > 0 && currentCol < len(b.grid[currentLine]) && b.grid[currentLine][currentCol].width == 0 {
-				b.grid[currentLine][currentCol-1] = spaceCell
-			}
-
-			if currentCol+charWidth-1 < len(b.grid[currentLine])-1 &&
-				b.grid[currentLine][currentCol+charWidth].width == 0 {
-				b.grid[currentLine][currentCol+charWidth] = spaceCell
-			}
-
-			c := gridCell{
-				Segment: Segment{
-					Text:  cluster,
-					Style: st.Style,
-				},
-				width: charWidth,
-			}
+// put draws the clusters on the row starting from col. The halves of the double width characters which
+// are partially overwritten are replaced with spaces, and the transparent clusters leave the row as it is.
+func put(row []gridCell, col int, clusters []gridCell) []gridCell {
+	for _, c := range clusters {
+		charWidth := c.width
+		for len(row) <= col+charWidth-1 {
+			row = append(row, spaceCell)
+		}
 
-			isTransparent := st.Style.GetBackground() == TransparentBg && st.Style.GetForeground() == TransparentFg
+		if col > 0 && col < len(row) && row[col].width == 0 {
+			row[col-1] = spaceCell
+		}
 
-			// Ensure the grid has enough space for the current character
-			if currentLine < len(b.grid) && !isTransparent {
-				if currentCol < len(b.grid[currentLine]) {
-					b.grid[currentLine][currentCol] = c
-				}
-			}
+		if col+charWidth-1 < len(row)-1 && row[col+charWidth].width == 0 {
+			row[col+charWidth] = spaceCell
+		}
 
-			if charWidth == 2 && currentCol+1 < len(b.grid[currentLine]) {
-				b.grid[currentLine][currentCol+1] = emptyCell
-			}
+		isTransparent := c.Style.GetBackground() == TransparentBg && c.Style.GetForeground() == TransparentFg
+		if !isTransparent && col < len(row) {
+			row[col] = c
+		}
 
-			currentCol += charWidth
+		if charWidth == 2 && col+1 < len(row) {
+			row[col+1] = emptyCell
 		}
+
+		col += charWidth
 	}
+	return row
 }
 
-func (b *cellBuffer) String() string {
-	var segments [][]*Segment
-
-	for _, line := range b.grid {
-		var lineSegments []*Segment
-		var lastSegment *Segment
-		for i := 0; i < len(line); i++ {
-			c := &line[i]
-			if c.width == 0 {
-				continue
-			}
-			if lastSegment == nil || !lastSegment.StyleEqual(c.Segment) {
-				if lastSegment != nil {
-					lineSegments = append(lineSegments, lastSegment)
-				}
-				lastSegment = &Segment{
-					Text:  c.Text,
-					Style: c.Style,
-				}
-			} else {
-				lastSegment.Text += c.Text
-			}
+// renderRow renders the cells of a row, merging the neighbouring cells with the same style
+func renderRow(row []gridCell) string {
+	var sb, text strings.Builder
+	var last *Segment
+	for i := range row {
+		c := &row[i]
+		if c.width == 0 {
+			continue
 		}
-		if lastSegment != nil {
-			lineSegments = append(lineSegments, lastSegment)
+		if last == nil || !last.StyleEqual(c.Segment) {
+			if last != nil {
+				sb.WriteString(Segment{Text: text.String(), Style: last.Style}.String())
+				text.Reset()
+			}
+			last = &c.Segment
 		}
-		segments = append(segments, lineSegments)
+		text.WriteString(c.Text)
 	}
-
-	var sb strings.Builder
-	for lineNum, lineStyles := range segments {
-		if lineNum > 0 {
-			sb.WriteByte('\n')
-		}
-		for _, style := range lineStyles {
-			sb.WriteString(style.String())
-		}
+	if last != nil {
+		sb.WriteString(Segment{Text: text.String(), Style: last.Style}.String())
 	}
 	return sb.String()
 }
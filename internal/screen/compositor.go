@@ -0,0 +1,87 @@
+package screen
+
+import (
+	"slices"
+	"strings"
+)
+
+// Layer is a view drawn on top of the base view with its top left corner at X, Y
+type Layer struct {
+	Content string
+	X       int
+	Y       int
+}
+
+// Compositor draws layers on top of a base view at cell level. Only the lines of the base covered by the
+// layers are parsed into cells, and the parsed lines and layers are kept for as long as they are drawn in
+// the following frames, so that when only a part of the screen changes, e.g. the footer while typing in a
+// popup, the lines under the layers are not parsed again.
+type Compositor struct {
+	lines  map[string][]gridCell
+	layers map[string][][]gridCell
+}
+
+func NewCompositor() *Compositor {
+	return &Compositor{
+		lines:  make(map[string][]gridCell),
+		layers: make(map[string][][]gridCell),
+	}
+}
+
+// Compose returns the base with the layers drawn on top of it in order. Only the lines covered by the
+// layers are rendered again, the other lines of the base are used as they are.
+func (c *Compositor) Compose(base string, layers ...Layer) string {
+	if len(layers) == 0 {
+		return base
+	}
+	baseLines := strings.Split(base, "\n")
+
+	// the lines and layers which are not drawn in this frame are dropped
+	parsedLines := make(map[string][]gridCell)
+	parsed := make(map[string][][]gridCell, len(layers))
+	rows := make(map[int][]gridCell)
+	height := len(baseLines)
+	for _, layer := range layers {
+		lines, ok := parsed[layer.Content]
+		if !ok {
+			if lines, ok = c.layers[layer.Content]; !ok {
+				lines = parseCells(layer.Content)
+			}
+			parsed[layer.Content] = lines
+		}
+		x, y := max(layer.X, 0), max(layer.Y, 0)
+		for i, line := range lines {
+			row, ok := rows[y+i]
+			if !ok && y+i < len(baseLines) {
+				row = slices.Clone(c.parseLine(baseLines[y+i], parsedLines))
+			}
+			rows[y+i] = put(row, x, line)
+		}
+		height = max(height, y+len(lines))
+	}
+	c.lines = parsedLines
+	c.layers = parsed
+
+	output := make([]string, height)
+	for i := range output {
+		if row, ok := rows[i]; ok {
+			output[i] = renderRow(row)
+		} else if i < len(baseLines) {
+			output[i] = baseLines[i]
+		}
+	}
+	return strings.Join(output, "\n")
+}
+
+// parseLine returns the cells of a line of the base, parsing it only if it was not drawn in the previous frame
+func (c *Compositor) parseLine(line string, parsedLines map[string][]gridCell) []gridCell {
+	if row, ok := parsedLines[line]; ok {
+		return row
+	}
+	row, ok := c.lines[line]
+	if !ok {
+		row = put(nil, 0, parseCells(line)[0])
+	}
+	parsedLines[line] = row
+	return row
+}
@@ -0,0 +1,134 @@
+package screen
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompositor_Compose(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     string
+		layers   []Layer
+		expected string
+	}{
+		{
+			name:     "no layers",
+			base:     "abc\ndef",
+			expected: "abc\ndef",
+		},
+		{
+			name:     "only the covered cells change",
+			base:     "abcd\nefgh\nijkl",
+			layers:   []Layer{{Content: "XY", X: 1, Y: 1}},
+			expected: "abcd\neXYh\nijkl",
+		},
+		{
+			name:     "layers are drawn in order",
+			base:     "abcd\nefgh",
+			layers:   []Layer{{Content: "XY", X: 1, Y: 0}, {Content: "Z", X: 2, Y: 0}},
+			expected: "aXZd\nefgh",
+		},
+		{
+			name:     "layer beyond the base extends it",
+			base:     "ab",
+			layers:   []Layer{{Content: "X", X: 3, Y: 1}},
+			expected: "ab\n   X",
+		},
+		{
+			name:     "negative position is clamped",
+			base:     "abc",
+			layers:   []Layer{{Content: "X", X: -2, Y: -1}},
+			expected: "Xbc",
+		},
+		{
+			name:     "overwritten half of a double width character",
+			base:     "🤬.",
+			layers:   []Layer{{Content: "|", X: 1, Y: 0}},
+			expected: " |.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewCompositor().Compose(tt.base, tt.layers...))
+		})
+	}
+}
+
+func TestCompositor_TransparentCells(t *testing.T) {
+	transparent := "\x1b[38;2;1;2;3;48;2;1;2;3m  \x1b[0m"
+	layer := "X" + transparent + "Y"
+	assert.Equal(t, "XbcY", stripAnsi(NewCompositor().Compose("abcd", Layer{Content: layer})))
+}
+
+func TestCompositor_ReusesUnchangedViews(t *testing.T) {
+	var lines []string
+	for i := range 10 {
+		lines = append(lines, strings.Repeat(strconv.Itoa(i), 10))
+	}
+	base := strings.Join(lines, "\n")
+	compositor := NewCompositor()
+
+	compositor.Compose(base, Layer{Content: "popup", X: 2, Y: 2})
+	assert.Len(t, compositor.lines, 1, "lines which are not covered are parsed")
+	covered := compositor.lines[lines[2]]
+	popup := compositor.layers["popup"]
+
+	lines[9] = "footer"
+	output := strings.Split(compositor.Compose(strings.Join(lines, "\n"), Layer{Content: "popup", X: 2, Y: 2}, Layer{Content: "search", X: 2, Y: 4}), "\n")
+	assert.Equal(t, "22popup222", output[2])
+	assert.Equal(t, "44search44", output[4])
+	assert.Equal(t, "footer", output[9])
+	assert.Same(t, &covered[0], &compositor.lines[lines[2]][0], "unchanged line is parsed again")
+	assert.Same(t, &popup[0], &compositor.layers["popup"][0], "unchanged layer is parsed again")
+
+	compositor.Compose(base, Layer{Content: "search", X: 2, Y: 4})
+	assert.NotContains(t, compositor.layers, "popup", "layer which is not drawn is kept")
+	assert.NotContains(t, compositor.lines, lines[2], "line which is not covered is kept")
+}
+
+func stripAnsi(s string) string {
+	var sb strings.Builder
+	for segment := range ParseFromReader(strings.NewReader(s)) {
+		sb.WriteString(segment.Text)
+	}
+	return sb.String()
+}
+
+func BenchmarkCompositor_Compose(b *testing.B) {
+	line := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Render("○  ") + strings.Repeat("lorem ipsum ", 20)
+	base := strings.Repeat(line+"\n", 100)
+	popup := lipgloss.NewStyle().Border(lipgloss.NormalBorder()).Width(60).Height(20).Render("menu")
+	b.Run("Stacked", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			Stacked(base, popup, 20, 20)
+		}
+	})
+	b.Run("Compositor", func(b *testing.B) {
+		compositor := NewCompositor()
+		b.ReportAllocs()
+		for b.Loop() {
+			compositor.Compose(base, Layer{Content: popup, X: 20, Y: 20})
+		}
+	})
+}
+
+// BenchmarkCompositor_FooterChanges composes a popup on a view of which only the footer changes between
+// the frames, e.g. while typing in the fuzzy search
+func BenchmarkCompositor_FooterChanges(b *testing.B) {
+	line := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Render("○  ") + strings.Repeat("lorem ipsum ", 20)
+	body := strings.Repeat(line+"\n", 100)
+	popup := lipgloss.NewStyle().Border(lipgloss.NormalBorder()).Width(60).Height(20).Render("menu")
+	compositor := NewCompositor()
+	b.ReportAllocs()
+	i := 0
+	for b.Loop() {
+		i++
+		compositor.Compose(body+"search: "+strconv.Itoa(i), Layer{Content: popup, X: 20, Y: 20})
+	}
+}
@@ -8,9 +8,11 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+// Segment is a run of text with the same style. The style is shared by the segments parsed from the same
+// escape sequences, so it must not be modified in place.
 type Segment struct {
 	Text  string
-	Style lipgloss.Style
+	Style *lipgloss.Style
 	Lane  uint64
 }
 
@@ -19,6 +21,9 @@ func (s Segment) String() string {
 }
 
 func (s Segment) StyleEqual(other Segment) bool {
+	if s.Style == other.Style {
+		return true
+	}
 	return s.Style.GetForeground() == other.Style.GetForeground() &&
 		s.Style.GetBackground() == other.Style.GetBackground() &&
 		s.Style.GetBold() == other.Style.GetBold() &&
@@ -55,7 +60,7 @@ func (s Segment) FindSubstringRange(substr string) (int, int) {
 
 // BreakNewLinesIter group segments into lines by breaking segments at new lines
 func BreakNewLinesIter(rawSegments <-chan *Segment) <-chan []*Segment {
-	output := make(chan []*Segment)
+	output := make(chan []*Segment, 64)
 	go func() {
 		defer close(output)
 		currentLine := make([]*Segment, 0)
@@ -189,36 +189,40 @@ func (m *Model) loadAll() tea.Msg {
 
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
-	case tea.KeyMsg:
+	case tea.KeyMsg, common.ActionMsg:
 		if m.menu.List.SettingFilter() {
 			break
 		}
 		switch {
-		case key.Matches(msg, m.keymap.Cancel):
+		case common.Matches(msg, "revisions.cancel", m.keymap.Cancel):
 			if m.menu.Filter != "" || m.menu.List.IsFiltered() {
 				m.menu.List.ResetFilter()
 				return m.filtered("")
 			}
 			return m, common.Close
-		case key.Matches(msg, m.keymap.Apply):
+		case common.Matches(msg, "revisions.apply", m.keymap.Apply):
 			if m.menu.List.SelectedItem() == nil {
 				break
 			}
 			action := m.menu.List.SelectedItem().(item)
 			return m, m.context.RunCommand(action.args, common.Refresh, common.Close)
-		case key.Matches(msg, m.keymap.Bookmark.Move) && m.menu.Filter != "move":
+		case common.Matches(msg, "bookmark.move", m.keymap.Bookmark.Move) && m.menu.Filter != "move":
 			return m.filtered("move")
-		case key.Matches(msg, m.keymap.Bookmark.Delete) && m.menu.Filter != "delete":
+		case common.Matches(msg, "bookmark.delete", m.keymap.Bookmark.Delete) && m.menu.Filter != "delete":
 			return m.filtered("delete")
-		case key.Matches(msg, m.keymap.Bookmark.Forget) && m.menu.Filter != "forget":
+		case common.Matches(msg, "bookmark.forget", m.keymap.Bookmark.Forget) && m.menu.Filter != "forget":
 			return m.filtered("forget")
-		case key.Matches(msg, m.keymap.Bookmark.Track) && m.menu.Filter != "track":
+		case common.Matches(msg, "bookmark.track", m.keymap.Bookmark.Track) && m.menu.Filter != "track":
 			return m.filtered("track")
-		case key.Matches(msg, m.keymap.Bookmark.Untrack) && m.menu.Filter != "untrack":
+		case common.Matches(msg, "bookmark.untrack", m.keymap.Bookmark.Untrack) && m.menu.Filter != "untrack":
 			return m.filtered("untrack")
 		default:
+			keyMsg, ok := msg.(tea.KeyMsg)
+			if !ok {
+				break
+			}
 			for _, listItem := range m.menu.List.Items() {
-				if item, ok := listItem.(item); ok && m.menu.Filter != "" && item.key == msg.String() {
+				if item, ok := listItem.(item); ok && m.menu.Filter != "" && item.key == keyMsg.String() {
 					return m, m.context.RunCommand(jj.Args(item.args...), common.Refresh, common.Close)
 				}
 			}
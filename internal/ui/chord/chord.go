@@ -0,0 +1,133 @@
+package chord
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/idursun/jjui/internal/config"
+)
+
+// Result tells what the resolver did with a key
+type Result int
+
+const (
+	// Unhandled keys are not part of any chord and should be processed as usual
+	Unhandled Result = iota
+	// Pending keys started or continued a chord, the resolver waits for the next key
+	Pending
+	// Completed keys finished a chord, the returned key should be processed instead
+	Completed
+	// Cancelled keys aborted the pending chord and should be dropped
+	Cancelled
+	// Replayed keys broke the pending chord, the returned keys should be processed instead: the pending
+	// keys followed by the key itself or the chord it completed. When the key starts another chord it is
+	// left out and kept pending.
+	Replayed
+)
+
+// TimeoutMsg is sent when no key followed the pending keys in time
+type TimeoutMsg struct {
+	id int
+}
+
+// Resolver collects key presses that make up multi-key bindings like "g p".
+// A completed chord is turned into a single tea.KeyMsg whose String() is the chord itself,
+// so key.Matches works with chord bindings unchanged.
+type Resolver struct {
+	chords  [][]string
+	names   []string
+	pending []tea.KeyMsg
+	timeout time.Duration
+	id      int
+}
+
+func New(chords []string, timeout time.Duration) *Resolver {
+	r := &Resolver{timeout: timeout}
+	for _, chord := range chords {
+		r.chords = append(r.chords, config.ChordKeys(chord))
+		r.names = append(r.names, chord)
+	}
+	return r
+}
+
+// Pending returns the keys typed so far of an unfinished chord
+func (r *Resolver) Pending() string {
+	var keys []string
+	for _, k := range r.pending {
+		keys = append(keys, k.String())
+	}
+	return strings.Join(keys, " ")
+}
+
+// Reset drops the pending keys
+func (r *Resolver) Reset() {
+	r.pending = nil
+	r.id++
+}
+
+// Feed processes a key press. The returned keys are processed in place of the key press and the returned
+// command schedules the timeout of a pending chord.
+func (r *Resolver) Feed(msg tea.KeyMsg, cancel func(tea.KeyMsg) bool) (Result, []tea.KeyMsg, tea.Cmd) {
+	if len(r.chords) == 0 {
+		return Unhandled, nil, nil
+	}
+	if len(r.pending) > 0 && cancel(msg) {
+		r.Reset()
+		return Cancelled, nil, nil
+	}
+
+	sequence := append(slices.Clone(r.pending), msg)
+	var typed []string
+	for _, k := range sequence {
+		typed = append(typed, k.String())
+	}
+
+	isPrefix := false
+	for i, chord := range r.chords {
+		if len(chord) < len(typed) || !slices.Equal(chord[:len(typed)], typed) {
+			continue
+		}
+		if len(chord) == len(typed) {
+			r.Reset()
+			return Completed, []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune(r.names[i])}}, nil
+		}
+		isPrefix = true
+	}
+
+	if isPrefix {
+		r.pending = sequence
+		r.id++
+		id := r.id
+		return Pending, nil, tea.Tick(r.timeout, func(time.Time) tea.Msg {
+			return TimeoutMsg{id: id}
+		})
+	}
+
+	if len(r.pending) > 0 {
+		// the sequence doesn't lead to any chord, the pending keys are processed as they were typed
+		// and the new key starts over
+		replay := r.pending
+		r.Reset()
+		result, keys, cmd := r.Feed(msg, cancel)
+		switch result {
+		case Unhandled:
+			replay = append(replay, msg)
+		case Completed:
+			replay = append(replay, keys...)
+		}
+		return Replayed, replay, cmd
+	}
+	return Unhandled, nil, nil
+}
+
+// Expire returns the pending keys of a timed out chord so that they can be processed one by one as usual
+func (r *Resolver) Expire(msg TimeoutMsg) []tea.KeyMsg {
+	if msg.id != r.id {
+		return nil
+	}
+	pending := r.pending
+	r.Reset()
+	return pending
+}
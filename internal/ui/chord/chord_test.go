@@ -0,0 +1,93 @@
+package chord
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func keyMsg(k string) tea.KeyMsg {
+	if k == "esc" {
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+func isEsc(msg tea.KeyMsg) bool {
+	return msg.Type == tea.KeyEsc
+}
+
+func TestResolver_CompletesChord(t *testing.T) {
+	r := New([]string{"g p", "g f"}, time.Second)
+
+	result, _, cmd := r.Feed(keyMsg("g"), isEsc)
+	assert.Equal(t, Pending, result)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "g", r.Pending())
+
+	result, keys, _ := r.Feed(keyMsg("p"), isEsc)
+	assert.Equal(t, Completed, result)
+	assert.Len(t, keys, 1)
+	assert.Equal(t, "g p", keys[0].String())
+	assert.Empty(t, r.Pending())
+}
+
+func TestResolver_UnrelatedKeys(t *testing.T) {
+	r := New([]string{"g p"}, time.Second)
+
+	result, _, _ := r.Feed(keyMsg("j"), isEsc)
+	assert.Equal(t, Unhandled, result)
+
+	r.Feed(keyMsg("g"), isEsc)
+	result, keys, _ := r.Feed(keyMsg("j"), isEsc)
+	assert.Equal(t, Replayed, result, "a key that breaks the chord replays the pending keys")
+	assert.Equal(t, []tea.KeyMsg{keyMsg("g"), keyMsg("j")}, keys)
+	assert.Empty(t, r.Pending())
+}
+
+func TestResolver_BreakingKeyStartsAnotherChord(t *testing.T) {
+	r := New([]string{"g p", "d d"}, time.Second)
+
+	r.Feed(keyMsg("g"), isEsc)
+	result, keys, cmd := r.Feed(keyMsg("d"), isEsc)
+	assert.Equal(t, Replayed, result)
+	assert.Equal(t, []tea.KeyMsg{keyMsg("g")}, keys)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "d", r.Pending())
+
+	result, keys, _ = r.Feed(keyMsg("d"), isEsc)
+	assert.Equal(t, Completed, result)
+	assert.Equal(t, "d d", keys[0].String())
+}
+
+func TestResolver_Cancel(t *testing.T) {
+	r := New([]string{"g p"}, time.Second)
+
+	r.Feed(keyMsg("g"), isEsc)
+	result, _, _ := r.Feed(keyMsg("esc"), isEsc)
+	assert.Equal(t, Cancelled, result)
+	assert.Empty(t, r.Pending())
+}
+
+func TestResolver_Timeout(t *testing.T) {
+	r := New([]string{"g p"}, time.Millisecond)
+
+	_, _, cmd := r.Feed(keyMsg("g"), isEsc)
+	timeout := cmd().(TimeoutMsg)
+	keys := r.Expire(timeout)
+	assert.Len(t, keys, 1)
+	assert.Equal(t, "g", keys[0].String())
+	assert.Empty(t, r.Pending())
+
+	assert.Empty(t, r.Expire(timeout), "an expired timeout doesn't replay anything")
+}
+
+func TestResolver_StaleTimeout(t *testing.T) {
+	r := New([]string{"g p", "g f"}, time.Millisecond)
+
+	_, _, cmd := r.Feed(keyMsg("g"), isEsc)
+	r.Feed(keyMsg("p"), isEsc)
+	assert.Empty(t, r.Expire(cmd().(TimeoutMsg)))
+}
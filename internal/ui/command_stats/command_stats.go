@@ -0,0 +1,139 @@
+package command_stats
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/context"
+)
+
+// Model shows the slowest jj invocations and the most frequently run jj subcommands of the session.
+// The invocations can be exported as a trace to be opened in chrome://tracing or Perfetto.
+type Model struct {
+	*common.Sizeable
+	context *context.MainContext
+	keyMap  config.KeyMappings[key.Binding]
+	// exportDir is where the traces are written
+	exportDir string
+}
+
+func (m *Model) ShortHelp() []key.Binding {
+	return []key.Binding{m.keyMap.CommandStats.Export, m.keyMap.Cancel}
+}
+
+func (m *Model) FullHelp() [][]key.Binding {
+	return [][]key.Binding{m.ShortHelp()}
+}
+
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case common.ConfigReloadedMsg:
+		m.keyMap = config.Current.GetKeyMap()
+	case tea.KeyMsg, common.ActionMsg:
+		switch {
+		case common.Matches(msg, "revisions.cancel", m.keyMap.Cancel), common.Matches(msg, "revisions.command_stats", m.keyMap.CommandStats.Mode):
+			return m, common.Close
+		case common.Matches(msg, "command_stats.export", m.keyMap.CommandStats.Export):
+			return m, m.export()
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) export() tea.Cmd {
+	recorder, dir := m.context.Recorder, m.exportDir
+	return func() tea.Msg {
+		path := filepath.Join(dir, fmt.Sprintf("jjui-trace-%s.json", time.Now().Format("20060102-150405")))
+		file, err := os.Create(path)
+		if err != nil {
+			return common.CommandCompletedMsg{Err: err}
+		}
+		defer file.Close()
+		if err := recorder.WriteChromeTrace(file); err != nil {
+			return common.CommandCompletedMsg{Err: err}
+		}
+		return common.CommandCompletedMsg{Output: fmt.Sprintf("Exported the trace to %s", path)}
+	}
+}
+
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%.1fms", float64(d.Microseconds())/1000)
+}
+
+func formatSize(size int) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1fMiB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1fKiB", float64(size)/(1<<10))
+	}
+	return fmt.Sprintf("%dB", size)
+}
+
+func (m *Model) View() string {
+	palette := common.DefaultPalette
+	border := palette.GetBorder("command_stats border", lipgloss.NormalBorder()).Padding(0, 1)
+	title := palette.Get("command_stats title")
+	text := palette.Get("command_stats text")
+	dimmed := palette.Get("command_stats dimmed")
+	failed := palette.Get("command_stats error")
+
+	width := max(m.Width-border.GetHorizontalFrameSize(), 20)
+	height := max(m.Height-border.GetVerticalFrameSize(), 8)
+
+	records := m.context.Recorder.Records()
+	var total time.Duration
+	for _, record := range records {
+		total += record.Duration
+	}
+	header := title.Render("jj invocations: ") + text.Render(fmt.Sprintf("%d in %s", len(records), formatDuration(total))) +
+		dimmed.Render(fmt.Sprintf("  (%s to export a chrome trace)", m.keyMap.CommandStats.Export.Help().Key))
+	lines := []string{header, ""}
+
+	// the rest of the lines are shared by the two lists and their titles
+	visible := max((height-len(lines)-3)/2, 1)
+
+	lines = append(lines, title.Render("Slowest"))
+	for _, record := range m.context.Recorder.Slowest(visible) {
+		status := dimmed.Render("  ")
+		if record.ExitCode != 0 {
+			status = failed.Render(fmt.Sprintf("%d ", record.ExitCode))
+		}
+		line := text.Render(fmt.Sprintf("%10s ", formatDuration(record.Duration))) + status +
+			dimmed.Render(fmt.Sprintf("%8s ", formatSize(record.OutputSize))) + text.Render(strings.Join(record.Args, " "))
+		lines = append(lines, lipgloss.NewStyle().MaxWidth(width).Render(line))
+	}
+
+	lines = append(lines, "", title.Render("Most frequent"))
+	for _, stat := range m.context.Recorder.MostFrequent(visible) {
+		line := text.Render(fmt.Sprintf("%6d× ", stat.Count)) +
+			dimmed.Render(fmt.Sprintf("total %10s  avg %10s  max %10s  ", formatDuration(stat.Total),
+				formatDuration(stat.Total/time.Duration(stat.Count)), formatDuration(stat.Max))) +
+			text.Render(stat.Name)
+		lines = append(lines, lipgloss.NewStyle().MaxWidth(width).Render(line))
+	}
+
+	content := lipgloss.Place(width, height, 0, 0, strings.Join(lines, "\n"), lipgloss.WithWhitespaceBackground(text.GetBackground()))
+	return border.Render(content)
+}
+
+func New(ctx *context.MainContext, width int, height int) *Model {
+	return &Model{
+		Sizeable:  &common.Sizeable{Width: width, Height: height},
+		context:   ctx,
+		keyMap:    config.Current.GetKeyMap(),
+		exportDir: os.TempDir(),
+	}
+}
@@ -0,0 +1,55 @@
+package command_stats
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/context"
+	"github.com/idursun/jjui/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newContext(t *testing.T) *context.MainContext {
+	ctx := test.NewTestContext(test.NewTestCommandRunner(t))
+	ctx.Recorder = context.NewCommandRecorder()
+	start := time.Now()
+	ctx.Recorder.Record(context.CommandRecord{Args: []string{"log", "-r", "::@"}, Start: start, Duration: 250 * time.Millisecond, OutputSize: 2048})
+	ctx.Recorder.Record(context.CommandRecord{Args: []string{"show", "-r", "abc"}, Start: start, Duration: 20 * time.Millisecond, ExitCode: 1})
+	ctx.Recorder.Record(context.CommandRecord{Args: []string{"show", "-r", "def"}, Start: start, Duration: 30 * time.Millisecond})
+	return ctx
+}
+
+func TestCommandStats_View(t *testing.T) {
+	model := New(newContext(t), 120, 20)
+	view := model.View()
+	assert.Contains(t, view, "3 in 300.0ms")
+	assert.Contains(t, view, "250.0ms")
+	assert.Contains(t, view, "2.0KiB")
+	assert.Contains(t, view, "log -r ::@")
+	assert.Contains(t, view, "2× total     50.0ms")
+}
+
+func TestCommandStats_Export(t *testing.T) {
+	model := New(newContext(t), 120, 20)
+	model.exportDir = t.TempDir()
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(common.CommandCompletedMsg)
+	require.True(t, ok)
+	require.NoError(t, msg.Err)
+
+	files, err := filepath.Glob(filepath.Join(model.exportDir, "jjui-trace-*.json"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Contains(t, msg.Output, files[0])
+	content, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.True(t, json.Valid(content))
+}
@@ -0,0 +1,53 @@
+package common
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ActionMsg runs a built-in action like `git.push` without going through the key bound to it. The models
+// handle it where they handle the key, Matches tells them which action it is by its name. Unlike a key
+// press it is never typed into an input.
+type ActionMsg struct {
+	Name string
+	// matched is set once a model matches the action, see Offer
+	matched *bool
+}
+
+// Group is the key mapping group of the action, e.g. `rebase` for `rebase.onto`
+func (a ActionMsg) Group() string {
+	group, _, _ := strings.Cut(a.Name, ".")
+	return group
+}
+
+// Matches reports whether msg is a key press matching the binding or the action of the binding, which is
+// named after its key mapping, e.g. `rebase.onto` for keys.rebase.onto. Actions are matched by their names
+// so that they work whichever keys are bound to them, and the actions bound to the same keys in different
+// modes, like `revisions.diff` and `details.diff`, are told apart.
+func Matches(msg tea.Msg, action string, binding key.Binding) bool {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return key.Matches(msg, binding)
+	case ActionMsg:
+		if msg.Name != action {
+			return false
+		}
+		if msg.matched != nil {
+			*msg.matched = true
+		}
+		return true
+	}
+	return false
+}
+
+// Offer passes the action to the model and reports whether the model matched it, so that the actions a
+// focused model does not handle can be handled by the model below it. The models offered actions check
+// their other conditions before Matches, an action is matched as soon as Matches reports it.
+func Offer(model tea.Model, msg ActionMsg) (tea.Model, tea.Cmd, bool) {
+	matched := false
+	msg.matched = &matched
+	model, cmd := model.Update(msg)
+	return model, cmd, matched
+}
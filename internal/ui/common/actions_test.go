@@ -0,0 +1,91 @@
+package common
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatches_TellsActionsWithTheSameKeysApart(t *testing.T) {
+	keyMap := config.Current.GetKeyMap()
+	msg := ActionMsg{Name: "revisions.diff"}
+	assert.True(t, Matches(msg, "revisions.diff", keyMap.Diff))
+	assert.False(t, Matches(msg, "details.diff", keyMap.Details.Diff))
+	assert.False(t, Matches(msg, "evolog.diff", keyMap.Evolog.Diff))
+
+	press := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(keyMap.Diff.Keys()[0])}
+	assert.True(t, Matches(press, "revisions.diff", keyMap.Diff))
+}
+
+type offered struct {
+	name string
+}
+
+func (o offered) Init() tea.Cmd { return nil }
+
+func (o offered) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	Matches(msg, o.name, key.NewBinding())
+	return o, nil
+}
+
+func (o offered) View() string { return "" }
+
+func TestOffer_ReportsWhetherTheModelMatched(t *testing.T) {
+	_, _, matched := Offer(offered{name: "details.diff"}, ActionMsg{Name: "details.diff"})
+	assert.True(t, matched)
+	_, _, matched = Offer(offered{name: "details.diff"}, ActionMsg{Name: "revisions.diff"})
+	assert.False(t, matched)
+}
+
+// actionName names the action of the key mapping field at path, e.g. `Rebase.Onto`
+func actionName(path string) string {
+	group, field, nested := strings.Cut(path, ".")
+	mappings := reflect.TypeFor[config.KeyMappings[key.Binding]]()
+	groupField, ok := mappings.FieldByName(group)
+	if !ok {
+		return ""
+	}
+	if !nested {
+		return config.ActionName("keys", groupField.Tag.Get("toml"))
+	}
+	actionField, ok := groupField.Type.FieldByName(field)
+	if !ok {
+		return ""
+	}
+	if action := actionField.Tag.Get("toml"); action != "mode" {
+		return config.ActionName("keys."+groupField.Tag.Get("toml"), action)
+	}
+	return config.ActionName("keys", groupField.Tag.Get("toml")+".mode")
+}
+
+func TestMatches_NamesTheActionOfTheBinding(t *testing.T) {
+	call := regexp.MustCompile(`common\.Matches\(\w+, "([^"]+)", \w+\.key[mM]ap\.([\w.]+)\)`)
+	root := filepath.Join("..", "..")
+	calls := 0
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, match := range call.FindAllStringSubmatch(string(content), -1) {
+			calls++
+			assert.Equal(t, actionName(match[2]), match[1], "%s matches %s under another name", path, match[2])
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Greater(t, calls, 100)
+}
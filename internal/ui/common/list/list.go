@@ -7,6 +7,12 @@ type IList interface {
 	GetItemRenderer(index int) IItemRenderer
 }
 
+// IItemHeight is implemented by the lists which can tell the height of an item without creating its
+// renderer, so that the rows scrolled out of the view are skipped cheaply
+type IItemHeight interface {
+	ItemHeight(index int) int
+}
+
 type IListCursor interface {
 	Cursor() int
 	SetCursor(index int)
@@ -67,21 +67,32 @@ func (r *ListRenderer) Render(focusIndex int) string {
 	selectedLineEnd := -1
 	firstRenderedRowIndex := -1
 	lastRenderedRowIndex := -1
+	heights, hasHeights := r.list.(IItemHeight)
+	r.scrollToFocused(focusIndex)
 	for i := range r.list.Len() {
 		isFocused := i == focusIndex
-		itemRenderer := r.list.GetItemRenderer(i)
+		var itemRenderer IItemRenderer
 		if isFocused {
 			selectedLineStart = r.totalLineCount()
 			if selectedLineStart < r.Start {
 				r.Start = selectedLineStart
 			}
 		} else {
-			rowLineCount := itemRenderer.Height()
+			var rowLineCount int
+			if hasHeights {
+				rowLineCount = heights.ItemHeight(i)
+			} else {
+				itemRenderer = r.list.GetItemRenderer(i)
+				rowLineCount = itemRenderer.Height()
+			}
 			if rowLineCount+r.totalLineCount() < r.Start {
 				r.skipLines(rowLineCount)
 				continue
 			}
 		}
+		if itemRenderer == nil {
+			itemRenderer = r.list.GetItemRenderer(i)
+		}
 		itemRenderer.Render(r, r.ViewRange.Width)
 		if firstRenderedRowIndex == -1 {
 			firstRenderedRowIndex = i
@@ -113,6 +124,24 @@ func (r *ListRenderer) Render(focusIndex int) string {
 	return r.String(r.Start, r.End)
 }
 
+// scrollToFocused moves the view down to the focused item before rendering, so that the items between
+// the view and an item far below it, e.g. after jumping to the end of a long list, are skipped rather
+// than rendered. It is only done when the heights of the items are known without rendering them.
+func (r *ListRenderer) scrollToFocused(focusIndex int) {
+	heights, ok := r.list.(IItemHeight)
+	if !ok || focusIndex < 0 || focusIndex >= r.list.Len() {
+		return
+	}
+	focusedLineEnd := 0
+	for i := 0; i <= focusIndex; i++ {
+		focusedLineEnd += heights.ItemHeight(i)
+	}
+	if focusedLineEnd > r.End {
+		r.End = focusedLineEnd
+		r.Start = max(focusedLineEnd-r.Height, 0)
+	}
+}
+
 func (r *ListRenderer) skipLines(amount int) {
 	r.skippedLineCount = r.skippedLineCount + amount
 }
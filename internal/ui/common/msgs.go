@@ -15,7 +15,9 @@ type (
 		SelectedRevision string
 		KeepSelections   bool
 	}
-	ShowDiffMsg              string
+	ShowDiffMsg string
+	// ShowPreviewContentMsg shows the output of a command in the preview pane until the selection changes
+	ShowPreviewContentMsg    string
 	UpdateRevisionsFailedMsg struct {
 		Output string
 		Err    error
@@ -43,13 +45,55 @@ type (
 		Commit       *jj.Commit
 		RawFileOut   []byte // raw output from `jj file list`
 	}
-	ShowPreview             bool
+	ShowPreview bool
+	// ConfigReloadedMsg is sent after the configuration has changed so that models rebuild their key maps and styles
+	ConfigReloadedMsg struct{}
+	// PromptMsg asks for a line of input in the status bar and runs the command Accept returns for it
+	PromptMsg struct {
+		Prompt string
+		Accept func(input string) tea.Cmd
+	}
+	// ConfirmMsg asks the user to confirm before running the command
+	ConfirmMsg struct {
+		Title     string
+		Message   string
+		Confirmed tea.Cmd
+	}
+	// ShowMenuMsg shows a menu of items, picking an item runs its command
+	ShowMenuMsg struct {
+		Title string
+		Items []MenuItem
+	}
+	// InvokeActionMsg runs a named action like `git.push` or `revset.set`, see MainContext.InvokeAction
+	InvokeActionMsg struct {
+		Name string
+		Args []string
+	}
+	// SwitchThemeMsg switches to a theme from the themes directory for the rest of the session
+	SwitchThemeMsg          string
 	StartSquashOperationMsg struct {
 		Revision *jj.Commit
 		Files    []string
 	}
+	// CommitsChangedMsg lists the working copy and the commits whose rows changed on reload while keeping their
+	// commit ids, e.g. when a bookmark moved. Their cached previews are out of date.
+	CommitsChangedMsg struct {
+		CommitIds []string
+	}
+	// StartCommitOperationMsg commits the working copy, only the given files when there are any
+	StartCommitOperationMsg struct {
+		Files []string
+	}
 )
 
+// MenuItem is an entry of the menu opened by ShowMenuMsg
+type MenuItem struct {
+	Label       string
+	Description string
+	Key         string
+	Command     tea.Cmd
+}
+
 type State int
 
 const (
@@ -91,6 +135,12 @@ func CommandRunning(args []string) tea.Cmd {
 	}
 }
 
+func InvokeAction(name string, args ...string) tea.Cmd {
+	return func() tea.Msg {
+		return InvokeActionMsg{Name: name, Args: args}
+	}
+}
+
 func UpdateRevSet(revset string) tea.Cmd {
 	return func() tea.Msg {
 		return UpdateRevSetMsg(revset)
@@ -1,8 +1,10 @@
 package common
 
 import (
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/idursun/jjui/internal/config"
 
@@ -14,10 +16,15 @@ var DefaultPalette = NewPalette()
 type node struct {
 	style    lipgloss.Style
 	children map[string]*node
+	// defined is false for the nodes that are only on the path to more specific keys
+	defined bool
+	origin  string
 }
 
 type Palette struct {
-	root  *node
+	root *node
+	// mu guards the styles and the cache, styles are looked up from the commands running in the background too
+	mu    sync.Mutex
 	cache map[string]lipgloss.Style
 }
 
@@ -29,6 +36,12 @@ func NewPalette() *Palette {
 }
 
 func (p *Palette) add(key string, style lipgloss.Style) {
+	p.addFrom("", key, style)
+}
+
+func (p *Palette) addFrom(origin string, key string, style lipgloss.Style) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	if p.root == nil {
 		p.root = &node{children: make(map[string]*node)}
 	}
@@ -44,6 +57,23 @@ func (p *Palette) add(key string, style lipgloss.Style) {
 		}
 	}
 	current.style = style
+	current.defined = true
+	current.origin = origin
+}
+
+func (p *Palette) find(fields ...string) *node {
+	if p.root == nil {
+		return nil
+	}
+	current := p.root
+	for _, field := range fields {
+		child, ok := current.children[field]
+		if !ok {
+			return nil
+		}
+		current = child
+	}
+	return current
 }
 
 func (p *Palette) get(fields ...string) lipgloss.Style {
@@ -63,26 +93,85 @@ func (p *Palette) get(fields ...string) lipgloss.Style {
 	return current.style
 }
 
+// Reset removes all styles so that the palette can be rebuilt from scratch
+func (p *Palette) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.root = nil
+	p.cache = make(map[string]lipgloss.Style)
+}
+
 func (p *Palette) Update(styleMap map[string]config.Color) {
+	p.UpdateFrom("", styleMap)
+}
+
+// UpdateFrom adds the styles and remembers the layer (e.g. "user theme") they came from
+func (p *Palette) UpdateFrom(origin string, styleMap map[string]config.Color) {
 	for key, color := range styleMap {
-		p.add(key, createStyleFrom(color))
+		p.addFrom(origin, key, createStyleFrom(color))
 	}
 
 	if color, ok := styleMap["diff added"]; ok {
-		p.add("added", createStyleFrom(color))
+		p.addFrom(origin, "added", createStyleFrom(color))
 	}
 	if color, ok := styleMap["diff renamed"]; ok {
-		p.add("renamed", createStyleFrom(color))
+		p.addFrom(origin, "renamed", createStyleFrom(color))
 	}
 	if color, ok := styleMap["diff modified"]; ok {
-		p.add("modified", createStyleFrom(color))
+		p.addFrom(origin, "modified", createStyleFrom(color))
 	}
 	if color, ok := styleMap["diff removed"]; ok {
-		p.add("deleted", createStyleFrom(color))
+		p.addFrom(origin, "deleted", createStyleFrom(color))
+	}
+}
+
+// Contribution is a defined style that takes part in resolving a selector
+type Contribution struct {
+	Key    string
+	Origin string
+}
+
+// Explain lists the defined styles that make up the style of the selector, most specific first,
+// in the same order Get inherits them
+func (p *Palette) Explain(selector string) []Contribution {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var contributions []Contribution
+	fields := strings.Fields(selector)
+	for start := 0; start < len(fields); start++ {
+		for end := len(fields); end > start; end-- {
+			if n := p.find(fields[start:end]...); n != nil && n.defined {
+				contributions = append(contributions, Contribution{Key: strings.Join(fields[start:end], " "), Origin: n.origin})
+			}
+		}
 	}
+	return contributions
+}
+
+// Selectors returns every selector the views look up and every key that has a style, sorted
+func (p *Palette) Selectors() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	selectors := slices.Clone(Selectors)
+	var walk func(prefix []string, n *node)
+	walk = func(prefix []string, n *node) {
+		if n.defined {
+			selectors = append(selectors, strings.Join(prefix, " "))
+		}
+		for field, child := range n.children {
+			walk(append(slices.Clone(prefix), field), child)
+		}
+	}
+	if p.root != nil {
+		walk(nil, p.root)
+	}
+	slices.Sort(selectors)
+	return slices.Compact(selectors)
 }
 
 func (p *Palette) Get(selector string) lipgloss.Style {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	if style, ok := p.cache[selector]; ok {
 		return style
 	}
@@ -143,6 +232,11 @@ func createStyleFrom(color config.Color) lipgloss.Style {
 	return style
 }
 
+// IsValidColor reports whether color is a hex, ANSI256 or named color that the palette understands
+func IsValidColor(color string) bool {
+	return color == "" || color == "default" || parseColor(color) != ""
+}
+
 func parseColor(color string) lipgloss.Color {
 	// if it's a hex color, return it directly
 	if len(color) == 7 && color[0] == '#' {
@@ -1,11 +1,20 @@
 package common
 
 import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/idursun/jjui/internal/config"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -245,3 +254,68 @@ func TestParseColor(t *testing.T) {
 		})
 	}
 }
+
+func TestPalette_Explain(t *testing.T) {
+	p := NewPalette()
+	p.UpdateFrom("embedded theme", map[string]config.Color{
+		"selected":           {Fg: "cyan"},
+		"revisions selected": {Bg: "blue"},
+	})
+	p.UpdateFrom("[ui.colors]", map[string]config.Color{
+		"selected": {Fg: "red"},
+	})
+
+	assert.Equal(t, []Contribution{
+		{Key: "revisions selected", Origin: "embedded theme"},
+		{Key: "selected", Origin: "[ui.colors]"},
+	}, p.Explain("revisions selected"))
+	assert.Empty(t, p.Explain("revisions"), "intermediate nodes are not styles")
+}
+
+func TestPalette_Selectors(t *testing.T) {
+	p := NewPalette()
+	p.Update(map[string]config.Color{"theme only": {Fg: "cyan"}})
+
+	selectors := p.Selectors()
+	assert.Contains(t, selectors, "preview border", "selectors of the views are listed before they are looked up")
+	assert.Contains(t, selectors, "git menu title")
+	assert.Contains(t, selectors, "theme only")
+	assert.True(t, slices.IsSorted(selectors))
+}
+
+// TestSelectors_ListsLookups checks that every selector the views look up by name is in Selectors
+func TestSelectors_ListsLookups(t *testing.T) {
+	lookup := regexp.MustCompile(`(?:Palette|palette)\.Get(?:Border)?\("([^"]+)"`)
+	root := filepath.Join("..", "..")
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, match := range lookup.FindAllStringSubmatch(string(content), -1) {
+			assert.Contains(t, Selectors, match[1], "%s looks up a selector which is not listed", path)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestPalette_ConcurrentLookups(t *testing.T) {
+	p := NewPalette()
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				p.Get(fmt.Sprintf("selector %d %d", i, j))
+				p.Update(map[string]config.Color{"text": {Fg: "white"}})
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, lipgloss.Color("7"), p.Get("text").GetForeground())
+}
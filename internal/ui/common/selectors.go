@@ -0,0 +1,45 @@
+package common
+
+// Selectors are the style keys looked up by the views, listed by the palette inspector. Views taking a style
+// prefix, like the menus and the confirmations, have their keys listed for each prefix they are used with.
+var Selectors = []string{
+	"dimmed", "error", "matched", "selected", "success", "text",
+	"abandon confirmation border", "abandon confirmation dimmed", "abandon confirmation selected",
+	"abandon confirmation text",
+	"bookmarks menu border", "bookmarks menu dimmed", "bookmarks menu matched", "bookmarks menu selected",
+	"bookmarks menu shortcut", "bookmarks menu text", "bookmarks menu title",
+	"command_stats border", "command_stats dimmed", "command_stats error", "command_stats text", "command_stats title",
+	"copy source_marker",
+	"custom_commands border", "custom_commands confirmation border", "custom_commands confirmation dimmed",
+	"custom_commands confirmation selected", "custom_commands confirmation text", "custom_commands menu border",
+	"custom_commands menu dimmed", "custom_commands menu matched", "custom_commands menu selected",
+	"custom_commands menu shortcut", "custom_commands menu text", "custom_commands menu title",
+	"duplicate change_id", "duplicate dimmed", "duplicate source_marker", "duplicate target_marker",
+	"evolog change_id", "evolog commit_id", "evolog dimmed", "evolog selected", "evolog target_marker", "evolog text",
+	"fix menu border", "fix menu dimmed", "fix menu matched", "fix menu selected", "fix menu shortcut", "fix menu text",
+	"fix menu title",
+	"git menu border", "git menu dimmed", "git menu matched", "git menu selected", "git menu shortcut", "git menu text",
+	"git menu title",
+	"help border", "help dimmed", "help shortcut", "help text", "help title",
+	"leader border", "leader dimmed", "leader group", "leader shortcut", "leader text", "leader title",
+	"menu border", "menu dimmed", "menu matched", "menu selected", "menu shortcut", "menu text", "menu title",
+	"metadata dimmed", "metadata text", "metadata title",
+	"new change_id", "new dimmed", "new source_marker", "new target_marker", "new text",
+	"oplog selected", "oplog text",
+	"palette border", "palette dimmed", "palette selected", "palette text", "palette title",
+	"preview border", "preview matched", "preview matched selected", "preview text",
+	"rebase change_id", "rebase dimmed", "rebase shortcut", "rebase source_marker", "rebase target_marker",
+	"revert change_id", "revert dimmed", "revert shortcut", "revert source_marker", "revert target_marker",
+	"revisions confirmation border", "revisions confirmation dimmed", "revisions confirmation selected",
+	"revisions confirmation text", "revisions details added", "revisions details conflict", "revisions details deleted",
+	"revisions details dimmed", "revisions details modified", "revisions details renamed", "revisions details selected",
+	"revisions details target_marker", "revisions details text", "revisions dimmed", "revisions selected",
+	"revisions signature bad", "revisions signature good", "revisions signature unknown", "revisions text",
+	"revset dimmed", "revset matched", "revset selected", "revset text", "revset title",
+	"set_parents dimmed", "set_parents source_marker", "set_parents target_marker",
+	"sign confirmation border", "sign confirmation dimmed", "sign confirmation selected", "sign confirmation text",
+	"squash dimmed", "squash source_marker", "squash target_marker",
+	"status dimmed", "status error", "status shortcut", "status success", "status text", "status title",
+	"undo border", "undo confirmation border", "undo confirmation dimmed", "undo confirmation selected",
+	"undo confirmation text",
+}
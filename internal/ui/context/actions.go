@@ -0,0 +1,117 @@
+package context
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/ui/common"
+)
+
+// handledActions run without going through a key binding, most of them take arguments
+var handledActions = map[string]func(ctx *MainContext, args []string) tea.Cmd{
+	// revset.set <revset> changes the revset of the revisions view
+	"revset.set": func(_ *MainContext, args []string) tea.Cmd {
+		if len(args) != 1 {
+			return actionError("revset.set needs a revset")
+		}
+		return common.UpdateRevSet(args[0])
+	},
+	// exec.jj <args...> runs a jj command the way the exec prompt does
+	"exec.jj": func(_ *MainContext, args []string) tea.Cmd {
+		return execAction(common.ExecJJ, args)
+	},
+	// exec.shell <line...> runs a shell command the way the exec prompt does
+	"exec.shell": func(_ *MainContext, args []string) tea.Cmd {
+		return execAction(common.ExecShell, args)
+	},
+	"revisions.refresh": func(*MainContext, []string) tea.Cmd {
+		return common.Refresh
+	},
+	"revisions.help": func(*MainContext, []string) tea.Cmd {
+		return common.ToggleHelp
+	},
+	"revisions.quit": func(*MainContext, []string) tea.Cmd {
+		return tea.Quit
+	},
+	// revisions.quick_search <text> searches for the text instead of opening the search prompt
+	"revisions.quick_search": func(ctx *MainContext, args []string) tea.Cmd {
+		if len(args) == 0 {
+			return builtinAction("revisions.quick_search")
+		}
+		return func() tea.Msg {
+			return common.QuickSearchMsg(strings.Join(args, " "))
+		}
+	},
+}
+
+// ActionNames lists the built-in actions and the custom commands that can be invoked by name
+func (ctx *MainContext) ActionNames() []string {
+	names := slices.Collect(maps.Keys(config.Current.Keys.Actions()))
+	for name := range handledActions {
+		names = append(names, name)
+	}
+	for name := range ctx.CustomCommands {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// IsKnownAction reports whether the name is a built-in action or the name of one of the custom commands
+func IsKnownAction(name string, customCommands map[string]CustomCommand) bool {
+	if _, ok := handledActions[name]; ok {
+		return true
+	}
+	if _, ok := config.Current.Keys.Actions()[name]; ok {
+		return true
+	}
+	_, ok := customCommands[name]
+	return ok
+}
+
+// InvokeAction runs the action with the given name. Built-in actions without a handler of their own are
+// sent as an ActionMsg to the models matching their names, so they work whichever keys are bound to them.
+// Custom commands are looked up by their name after the built-in actions.
+func (ctx *MainContext) InvokeAction(name string, args []string) tea.Cmd {
+	if handler, ok := handledActions[name]; ok {
+		return handler(ctx, args)
+	}
+	if _, ok := config.Current.Keys.Actions()[name]; ok {
+		if len(args) > 0 {
+			return actionError(fmt.Sprintf("%s does not take arguments", name))
+		}
+		return builtinAction(name)
+	}
+	if command, ok := ctx.CustomCommands[name]; ok {
+		if !command.IsApplicableTo(ctx.SelectedItem) {
+			return actionError(fmt.Sprintf("%s is not applicable to the selected item", name))
+		}
+		return command.Prepare(ctx)
+	}
+	return actionError(fmt.Sprintf("unknown action %q", name))
+}
+
+func builtinAction(name string) tea.Cmd {
+	return func() tea.Msg {
+		return common.ActionMsg{Name: name}
+	}
+}
+
+func execAction(mode common.ExecMode, args []string) tea.Cmd {
+	if len(args) == 0 {
+		return actionError("exec." + mode.Mode + " needs a command")
+	}
+	return func() tea.Msg {
+		return common.ExecMsg{Line: strings.Join(args, " "), Mode: mode}
+	}
+}
+
+func actionError(message string) tea.Cmd {
+	return func() tea.Msg {
+		return common.CommandCompletedMsg{Err: fmt.Errorf("%s", message)}
+	}
+}
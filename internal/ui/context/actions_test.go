@@ -0,0 +1,81 @@
+package context
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvokeAction(t *testing.T) {
+	customCommands, err := LoadCustomCommands(`
+[custom_commands."my revisions"]
+revset = "mine()"
+`)
+	require.NoError(t, err)
+	ctx := &MainContext{CustomCommands: customCommands, SelectedItem: SelectedRevision{ChangeId: "abc"}}
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected tea.Msg
+	}{
+		{name: "revset.set", args: []string{"trunk()"}, expected: common.UpdateRevSetMsg("trunk()")},
+		{name: "exec.jj", args: []string{"git", "fetch"}, expected: common.ExecMsg{Line: "git fetch", Mode: common.ExecJJ}},
+		{name: "revisions.quick_search", args: []string{"fix"}, expected: common.QuickSearchMsg("fix")},
+		{name: "git.push", expected: common.ActionMsg{Name: "git.push"}},
+		{name: "revisions.refresh", expected: common.RefreshMsg{}},
+		{name: "my revisions", expected: common.UpdateRevSetMsg("mine()")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ctx.InvokeAction(tt.name, tt.args)())
+		})
+	}
+}
+
+func TestInvokeAction_FollowsKeyBindings(t *testing.T) {
+	push := config.Current.Keys.Git.Push
+	defer func() { config.Current.Keys.Git.Push = push }()
+	ctx := &MainContext{}
+
+	config.Current.Keys.Git.Push = []string{"ctrl+p"}
+	action := ctx.InvokeAction("git.push", nil)().(common.ActionMsg)
+	assert.True(t, common.Matches(action, "git.push", config.Current.GetKeyMap().Git.Push))
+	assert.False(t, common.Matches(action, "git.fetch", config.Current.GetKeyMap().Git.Fetch))
+
+	config.Current.Keys.Git.Push = nil
+	action = ctx.InvokeAction("git.push", nil)().(common.ActionMsg)
+	assert.True(t, common.Matches(action, "git.push", config.Current.GetKeyMap().Git.Push), "expected an unbound action to be invoked by name")
+}
+
+func TestInvokeAction_Errors(t *testing.T) {
+	ctx := &MainContext{}
+	for name, args := range map[string][]string{
+		"no.such.action": nil,
+		"revset.set":     nil,
+		"revisions.new":  {"unexpected"},
+		"exec.shell":     nil,
+	} {
+		completed, ok := ctx.InvokeAction(name, args)().(common.CommandCompletedMsg)
+		require.True(t, ok, name)
+		assert.Error(t, completed.Err, name)
+	}
+}
+
+func TestCustomActionCommand(t *testing.T) {
+	customCommands, err := LoadCustomCommands(`
+[custom_commands."children"]
+action = ["revset.set", "$change_id::"]
+`)
+	require.NoError(t, err)
+	command := customCommands["children"]
+	require.IsType(t, CustomActionCommand{}, command)
+
+	ctx := &MainContext{CustomCommands: customCommands, SelectedItem: SelectedRevision{ChangeId: "abc"}}
+	assert.Equal(t, "action revset.set abc::", command.Description(ctx))
+	assert.Equal(t, common.InvokeActionMsg{Name: "revset.set", Args: []string{"abc::"}}, command.Prepare(ctx)())
+}
@@ -0,0 +1,76 @@
+package context
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// MaxConcurrentQueries is how many jj queries of the command queue run at the same time
+const MaxConcurrentQueries = 4
+
+// CommandQueue runs jj queries in the background so that Update never waits for jj. At most
+// MaxConcurrentQueries run at once and a query that is already running is not started again,
+// its callers share the output instead.
+type CommandQueue struct {
+	runner   CommandRunner
+	slots    chan struct{}
+	depth    atomic.Int32
+	mutex    sync.Mutex
+	inflight map[string]*query
+}
+
+type query struct {
+	done   chan struct{}
+	output []byte
+	err    error
+}
+
+func NewCommandQueue(runner CommandRunner, concurrency int) *CommandQueue {
+	return &CommandQueue{
+		runner:   runner,
+		slots:    make(chan struct{}, max(concurrency, 1)),
+		inflight: map[string]*query{},
+	}
+}
+
+// Query returns a command which runs `jj <args>` and turns its output into a message with result.
+// The query counts towards the depth of the queue from the moment it is created.
+func (q *CommandQueue) Query(args []string, result func(output []byte, err error) tea.Msg) tea.Cmd {
+	q.depth.Add(1)
+	return func() tea.Msg {
+		defer q.depth.Add(-1)
+		output, err := q.run(args)
+		return result(output, err)
+	}
+}
+
+// Depth is the number of queries that are waiting or running
+func (q *CommandQueue) Depth() int {
+	return int(q.depth.Load())
+}
+
+func (q *CommandQueue) run(args []string) ([]byte, error) {
+	key := strings.Join(args, "\x00")
+	q.mutex.Lock()
+	if running, ok := q.inflight[key]; ok {
+		q.mutex.Unlock()
+		<-running.done
+		return running.output, running.err
+	}
+	running := &query{done: make(chan struct{})}
+	q.inflight[key] = running
+	q.mutex.Unlock()
+
+	q.slots <- struct{}{}
+	running.output, running.err = q.runner.RunCommandImmediate(args)
+	<-q.slots
+
+	q.mutex.Lock()
+	delete(q.inflight, key)
+	q.mutex.Unlock()
+	close(running.done)
+	return running.output, running.err
+}
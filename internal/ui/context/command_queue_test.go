@@ -0,0 +1,94 @@
+package context
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingRunner holds every command until release is closed and records how many ran at once
+type blockingRunner struct {
+	CommandRunner
+	started chan string
+	release chan struct{}
+	calls   atomic.Int32
+	running atomic.Int32
+	peak    atomic.Int32
+}
+
+func newBlockingRunner() *blockingRunner {
+	return &blockingRunner{started: make(chan string, 16), release: make(chan struct{})}
+}
+
+func (r *blockingRunner) RunCommandImmediate(args []string) ([]byte, error) {
+	r.calls.Add(1)
+	running := r.running.Add(1)
+	defer r.running.Add(-1)
+	for peak := r.peak.Load(); running > peak && !r.peak.CompareAndSwap(peak, running); peak = r.peak.Load() {
+	}
+	r.started <- strings.Join(args, " ")
+	<-r.release
+	return []byte(strings.Join(args, " ")), nil
+}
+
+func output(out []byte, _ error) tea.Msg {
+	return string(out)
+}
+
+func runAll(cmds ...tea.Cmd) []tea.Msg {
+	msgs := make([]tea.Msg, len(cmds))
+	var wg sync.WaitGroup
+	for i, cmd := range cmds {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			msgs[i] = cmd()
+		}()
+	}
+	wg.Wait()
+	return msgs
+}
+
+func TestCommandQueue_DeduplicatesInflightQueries(t *testing.T) {
+	runner := newBlockingRunner()
+	queue := NewCommandQueue(runner, 2)
+
+	first := queue.Query([]string{"log"}, output)
+	second := queue.Query([]string{"log"}, output)
+	assert.Equal(t, 2, queue.Depth())
+
+	done := make(chan []tea.Msg)
+	go func() { done <- runAll(first, second) }()
+	<-runner.started
+	// the second query waits for the first one instead of running jj again
+	assert.Never(t, func() bool { return len(runner.started) > 0 }, 50*time.Millisecond, time.Millisecond)
+	close(runner.release)
+
+	assert.Equal(t, []tea.Msg{"log", "log"}, <-done)
+	assert.Equal(t, int32(1), runner.calls.Load())
+	assert.Equal(t, 0, queue.Depth())
+}
+
+func TestCommandQueue_LimitsConcurrency(t *testing.T) {
+	runner := newBlockingRunner()
+	queue := NewCommandQueue(runner, 2)
+
+	var cmds []tea.Cmd
+	for _, args := range []string{"a", "b", "c", "d"} {
+		cmds = append(cmds, queue.Query([]string{args}, output))
+	}
+	done := make(chan []tea.Msg)
+	go func() { done <- runAll(cmds...) }()
+	<-runner.started
+	<-runner.started
+	close(runner.release)
+
+	assert.ElementsMatch(t, []tea.Msg{"a", "b", "c", "d"}, <-done)
+	assert.Equal(t, int32(4), runner.calls.Load())
+	assert.LessOrEqual(t, runner.peak.Load(), int32(2))
+}
@@ -0,0 +1,192 @@
+package context
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"io"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxCommandRecords is how many invocations are kept, the oldest ones are dropped after that
+const maxCommandRecords = 10000
+
+// CommandRecord is a single jj invocation
+type CommandRecord struct {
+	Args     []string
+	Start    time.Time
+	Duration time.Duration
+	// ExitCode is -1 when jj could not be started or was killed
+	ExitCode   int
+	OutputSize int
+}
+
+// Name is the jj subcommand of the invocation, e.g. `log` or `bookmark list`
+func (r CommandRecord) Name() string {
+	var words []string
+	for _, arg := range r.Args {
+		if strings.HasPrefix(arg, "-") || len(words) == 2 {
+			break
+		}
+		words = append(words, arg)
+	}
+	if len(words) == 0 {
+		return "jj"
+	}
+	return strings.Join(words, " ")
+}
+
+// CommandStat sums up the invocations of a subcommand
+type CommandStat struct {
+	Name  string
+	Count int
+	Total time.Duration
+	Max   time.Duration
+}
+
+// CommandRecorder keeps the jj invocations of the session. It is safe to use from the goroutines of tea.Cmds
+// and a nil recorder records nothing.
+type CommandRecorder struct {
+	mu      sync.Mutex
+	records []CommandRecord
+}
+
+func NewCommandRecorder() *CommandRecorder {
+	return &CommandRecorder{}
+}
+
+func (r *CommandRecorder) Record(record CommandRecord) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.records) == maxCommandRecords {
+		r.records = slices.Delete(r.records, 0, 1)
+	}
+	r.records = append(r.records, record)
+}
+
+// record records an invocation which started at start and ended with err
+func (r *CommandRecorder) record(args []string, start time.Time, outputSize int, err error) {
+	r.Record(CommandRecord{
+		Args:       slices.Clone(args),
+		Start:      start,
+		Duration:   time.Since(start),
+		ExitCode:   exitCode(err),
+		OutputSize: outputSize,
+	})
+}
+
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitError *exec.ExitError
+	if errors.As(err, &exitError) {
+		return exitError.ExitCode()
+	}
+	return -1
+}
+
+// Records returns the invocations in the order they started
+func (r *CommandRecorder) Records() []CommandRecord {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	records := slices.Clone(r.records)
+	r.mu.Unlock()
+	slices.SortStableFunc(records, func(a, b CommandRecord) int {
+		return a.Start.Compare(b.Start)
+	})
+	return records
+}
+
+// Slowest returns the n longest invocations, longest first
+func (r *CommandRecorder) Slowest(n int) []CommandRecord {
+	records := r.Records()
+	slices.SortStableFunc(records, func(a, b CommandRecord) int {
+		return cmp.Compare(b.Duration, a.Duration)
+	})
+	return records[:min(n, len(records))]
+}
+
+// MostFrequent returns the stats of the n most invoked subcommands, the most invoked first
+func (r *CommandRecorder) MostFrequent(n int) []CommandStat {
+	index := make(map[string]int)
+	var stats []CommandStat
+	for _, record := range r.Records() {
+		name := record.Name()
+		i, ok := index[name]
+		if !ok {
+			i = len(stats)
+			index[name] = i
+			stats = append(stats, CommandStat{Name: name})
+		}
+		stats[i].Count++
+		stats[i].Total += record.Duration
+		stats[i].Max = max(stats[i].Max, record.Duration)
+	}
+	slices.SortStableFunc(stats, func(a, b CommandStat) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return cmp.Compare(b.Total, a.Total)
+	})
+	return stats[:min(n, len(stats))]
+}
+
+type traceEvent struct {
+	Name     string         `json:"name"`
+	Category string         `json:"cat"`
+	Phase    string         `json:"ph"`
+	Time     int64          `json:"ts"`
+	Duration int64          `json:"dur"`
+	Pid      int            `json:"pid"`
+	Tid      int            `json:"tid"`
+	Args     map[string]any `json:"args"`
+}
+
+// WriteChromeTrace writes the invocations in the trace event format of chrome://tracing and Perfetto.
+// Invocations running at the same time are put on separate threads so that they do not overlap.
+func (r *CommandRecorder) WriteChromeTrace(w io.Writer) error {
+	records := r.Records()
+	events := make([]traceEvent, 0, len(records))
+	var lanes []time.Time
+	for _, record := range records {
+		end := record.Start.Add(record.Duration)
+		lane := slices.IndexFunc(lanes, func(free time.Time) bool {
+			return !free.After(record.Start)
+		})
+		if lane == -1 {
+			lane = len(lanes)
+			lanes = append(lanes, end)
+		}
+		lanes[lane] = end
+		events = append(events, traceEvent{
+			Name:     record.Name(),
+			Category: "jj",
+			Phase:    "X",
+			Time:     record.Start.UnixMicro(),
+			Duration: record.Duration.Microseconds(),
+			Pid:      1,
+			Tid:      lane + 1,
+			Args: map[string]any{
+				"args":        strings.Join(record.Args, " "),
+				"exit_code":   record.ExitCode,
+				"output_size": record.OutputSize,
+			},
+		})
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", " ")
+	return encoder.Encode(map[string]any{
+		"traceEvents":     events,
+		"displayTimeUnit": "ms",
+	})
+}
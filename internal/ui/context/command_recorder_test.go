@@ -0,0 +1,73 @@
+package context
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandRecord_Name(t *testing.T) {
+	assert.Equal(t, "log", CommandRecord{Args: []string{"log", "-r", "::@"}}.Name())
+	assert.Equal(t, "bookmark list", CommandRecord{Args: []string{"bookmark", "list", "--all"}}.Name())
+	assert.Equal(t, "op log", CommandRecord{Args: []string{"op", "log", "@"}}.Name())
+	assert.Equal(t, "jj", CommandRecord{Args: []string{"--version"}}.Name())
+}
+
+func TestCommandRecorder_Stats(t *testing.T) {
+	recorder := NewCommandRecorder()
+	start := time.Now()
+	recorder.Record(CommandRecord{Args: []string{"log"}, Start: start, Duration: 300 * time.Millisecond})
+	recorder.Record(CommandRecord{Args: []string{"show", "-r", "a"}, Start: start.Add(time.Second), Duration: 10 * time.Millisecond})
+	recorder.Record(CommandRecord{Args: []string{"show", "-r", "b"}, Start: start.Add(2 * time.Second), Duration: 50 * time.Millisecond})
+
+	slowest := recorder.Slowest(2)
+	require.Len(t, slowest, 2)
+	assert.Equal(t, []string{"log"}, slowest[0].Args)
+	assert.Equal(t, []string{"show", "-r", "b"}, slowest[1].Args)
+
+	frequent := recorder.MostFrequent(5)
+	assert.Equal(t, []CommandStat{
+		{Name: "show", Count: 2, Total: 60 * time.Millisecond, Max: 50 * time.Millisecond},
+		{Name: "log", Count: 1, Total: 300 * time.Millisecond, Max: 300 * time.Millisecond},
+	}, frequent)
+}
+
+func TestCommandRecorder_NilRecordsNothing(t *testing.T) {
+	var recorder *CommandRecorder
+	recorder.Record(CommandRecord{Args: []string{"log"}})
+	assert.Empty(t, recorder.Records())
+	assert.Empty(t, recorder.MostFrequent(5))
+}
+
+func TestCommandRecorder_WriteChromeTrace(t *testing.T) {
+	recorder := NewCommandRecorder()
+	start := time.UnixMicro(1_000_000)
+	recorder.Record(CommandRecord{Args: []string{"log", "-r", "::@"}, Start: start, Duration: 100 * time.Millisecond, OutputSize: 42})
+	recorder.Record(CommandRecord{Args: []string{"show"}, Start: start.Add(50 * time.Millisecond), Duration: 10 * time.Millisecond, ExitCode: 1})
+	recorder.Record(CommandRecord{Args: []string{"diff"}, Start: start.Add(200 * time.Millisecond), Duration: 10 * time.Millisecond})
+
+	var buffer bytes.Buffer
+	require.NoError(t, recorder.WriteChromeTrace(&buffer))
+	var trace struct {
+		TraceEvents []traceEvent `json:"traceEvents"`
+	}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &trace))
+	require.Len(t, trace.TraceEvents, 3)
+
+	log := trace.TraceEvents[0]
+	assert.Equal(t, "log", log.Name)
+	assert.Equal(t, "X", log.Phase)
+	assert.Equal(t, int64(1_000_000), log.Time)
+	assert.Equal(t, int64(100_000), log.Duration)
+	assert.Equal(t, "log -r ::@", log.Args["args"])
+	assert.Equal(t, float64(42), log.Args["output_size"])
+
+	assert.Equal(t, 1, log.Tid)
+	assert.Equal(t, 2, trace.TraceEvents[1].Tid, "expected overlapping invocations to be on separate threads")
+	assert.Equal(t, float64(1), trace.TraceEvents[1].Args["exit_code"])
+	assert.Equal(t, 1, trace.TraceEvents[2].Tid)
+}
@@ -10,6 +10,7 @@ import (
 	"os/exec"
 	"slices"
 	"sync"
+	"time"
 
 	"github.com/charmbracelet/bubbletea"
 	"github.com/idursun/jjui/internal/ui/common"
@@ -24,12 +25,17 @@ type CommandRunner interface {
 
 type MainCommandRunner struct {
 	Location string
+	// Recorder keeps every invocation of jj for the command stats panel
+	Recorder *CommandRecorder
 }
 
 func (a *MainCommandRunner) RunCommandImmediate(args []string) ([]byte, error) {
 	c := exec.Command("jj", args...)
 	c.Dir = a.Location
-	if output, err := c.Output(); err != nil {
+	start := time.Now()
+	output, err := c.Output()
+	a.Recorder.record(args, start, len(output), err)
+	if err != nil {
 		var exitError *exec.ExitError
 		if errors.As(err, &exitError) {
 			return nil, errors.New(string(exitError.Stderr))
@@ -51,7 +57,9 @@ func (a *MainCommandRunner) RunCommandStreaming(ctx context.Context, args []stri
 	if err != nil {
 		return nil, err
 	}
+	start := time.Now()
 	if err = c.Start(); err != nil {
+		a.Recorder.record(args, start, 0, err)
 		return nil, err
 	}
 	return &StreamingCommand{
@@ -59,6 +67,9 @@ func (a *MainCommandRunner) RunCommandStreaming(ctx context.Context, args []stri
 		ErrPipe:    errPipe,
 		cmd:        c,
 		ctx:        ctx,
+		recorder:   a.Recorder,
+		args:       args,
+		start:      start,
 	}, nil
 }
 
@@ -73,7 +84,9 @@ func (a *MainCommandRunner) RunCommand(args []string, continuations ...tea.Cmd)
 			c.Dir = a.Location
 			var output bytes.Buffer
 			c.Stderr = &output
-			_, err := c.Output()
+			start := time.Now()
+			stdout, err := c.Output()
+			a.Recorder.record(args, start, len(stdout)+output.Len(), err)
 			if err != nil {
 				var exitError *exec.ExitError
 				if errors.As(err, &exitError) {
@@ -97,9 +110,12 @@ func (a *MainCommandRunner) RunInteractiveCommand(args []string, continuation te
 	errBuffer := &bytes.Buffer{}
 	c.Stderr = errBuffer
 	c.Dir = a.Location
+	start := time.Now()
 	return tea.Batch(
 		common.CommandRunning(args),
 		tea.ExecProcess(c, func(err error) tea.Msg {
+			// the time is spent in the interactive program, e.g. the diff editor, rather than in jj itself
+			a.Recorder.record(args, start, 0, err)
 			if err != nil {
 				return common.CommandCompletedMsg{Err: errors.New(errBuffer.String())}
 			}
@@ -112,10 +128,20 @@ func (a *MainCommandRunner) RunInteractiveCommand(args []string, continuation te
 
 type StreamingCommand struct {
 	io.ReadCloser
-	ErrPipe io.ReadCloser
-	cmd     *exec.Cmd
-	ctx     context.Context
-	once    sync.Once
+	ErrPipe  io.ReadCloser
+	cmd      *exec.Cmd
+	ctx      context.Context
+	once     sync.Once
+	recorder *CommandRecorder
+	args     []string
+	start    time.Time
+	read     int
+}
+
+func (c *StreamingCommand) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.read += n
+	return n, err
 }
 
 func (c *StreamingCommand) Close() error {
@@ -123,6 +149,10 @@ func (c *StreamingCommand) Close() error {
 	c.once.Do(func() {
 		log.Println("closing streaming command")
 		pipeErr := c.ReadCloser.Close()
+		if c.cmd == nil {
+			err = pipeErr
+			return
+		}
 
 		if c.ctx.Err() != nil {
 			log.Println("killing process due to context cancellation")
@@ -134,6 +164,7 @@ func (c *StreamingCommand) Close() error {
 
 		log.Println("waiting for command to finish")
 		err = c.cmd.Wait()
+		c.recorder.record(c.args, c.start, c.read, err)
 		if err != nil && (c.ctx.Err() != nil || errors.Is(err, os.ErrClosed)) {
 			err = nil
 		}
@@ -0,0 +1,121 @@
+package context
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/common"
+)
+
+// LoadedConfig holds everything that is built from config.toml and the theme files
+type LoadedConfig struct {
+	Config         *config.Config
+	CustomCommands map[string]CustomCommand
+	Leader         LeaderMap
+	ThemeLayers    []config.ThemeLayer
+}
+
+// ConfigLayers reads the user config.toml followed by the repository overlays: the `[jjui]` table of
+// the repo's jj config and `.jj/jjui.toml`
+func (ctx *MainContext) ConfigLayers() ([]config.Layer, error) {
+	user, err := config.LoadUserLayer()
+	if err != nil {
+		return nil, err
+	}
+	layers := []config.Layer{user}
+
+	// jj fails when the repo config has no jjui table, which is the common case
+	if output, err := ctx.RunCommandImmediate(jj.ConfigListRepo("jjui")); err == nil {
+		data, err := config.ExtractTable(string(output), "jjui")
+		if err != nil {
+			return nil, fmt.Errorf("reading [jjui] from the repo config: %w", err)
+		}
+		if data != "" {
+			layers = append(layers, config.Layer{Name: config.RepoLayer, Source: "jj config --repo", Data: data})
+		}
+	}
+
+	repoConfigFile := config.RepoConfigFilePath(ctx.Location)
+	data, err := os.ReadFile(repoConfigFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		layers = append(layers, config.Layer{Name: config.RepoLayer, Source: repoConfigFile, Data: string(data)})
+	}
+	return layers, nil
+}
+
+// LoadConfig runs all the loaders against the config layers without touching the current configuration,
+// so that a failing reload keeps the previous configuration in place
+func LoadConfig(layers []config.Layer, darkBackground bool) (*LoadedConfig, error) {
+	c, err := config.LoadLayers(layers)
+	if err != nil {
+		return nil, fmt.Errorf("loading configuration: %w", err)
+	}
+	var contents []string
+	for _, layer := range layers {
+		contents = append(contents, layer.Data)
+	}
+	customCommands, err := LoadCustomCommands(contents...)
+	if err != nil {
+		return nil, fmt.Errorf("loading custom commands: %w", err)
+	}
+	leader, err := LoadLeader(contents...)
+	if err != nil {
+		return nil, fmt.Errorf("loading leader keys: %w", err)
+	}
+	themeLayers, err := c.LoadThemeLayers(darkBackground)
+	if err != nil {
+		return nil, err
+	}
+	return &LoadedConfig{
+		Config:         c,
+		CustomCommands: customCommands,
+		Leader:         leader,
+		ThemeLayers:    themeLayers,
+	}, nil
+}
+
+// ApplyConfig makes the loaded configuration current and rebuilds the palette
+func (ctx *MainContext) ApplyConfig(loaded *LoadedConfig) {
+	config.Current = loaded.Config
+	if ctx.ConfigOverrides != nil {
+		ctx.ConfigOverrides(config.Current)
+	}
+	ctx.CustomCommands = loaded.CustomCommands
+	ctx.Leader = loaded.Leader
+
+	ctx.rebuildPalette(loaded.ThemeLayers)
+}
+
+// SwitchTheme makes the named theme from the themes directory current for the rest of the session,
+// an empty name switches back to the embedded theme
+func (ctx *MainContext) SwitchTheme(name string) error {
+	c := *config.Current
+	if ctx.DarkBackground {
+		c.UI.Theme.Dark = name
+	} else {
+		c.UI.Theme.Light = name
+	}
+	themeLayers, err := c.LoadThemeLayers(ctx.DarkBackground)
+	if err != nil {
+		return err
+	}
+	config.Current.UI.Theme = c.UI.Theme
+	ctx.rebuildPalette(themeLayers)
+	return nil
+}
+
+func (ctx *MainContext) rebuildPalette(themeLayers []config.ThemeLayer) {
+	common.DefaultPalette.Reset()
+	for _, layer := range themeLayers {
+		common.DefaultPalette.UpdateFrom(layer.Name, layer.Colors)
+	}
+	common.DefaultPalette.UpdateFrom("jj colors", ctx.JJConfig.GetApplicableColors())
+	common.DefaultPalette.UpdateFrom("[ui.colors]", config.Current.UI.Colors)
+}
@@ -0,0 +1,48 @@
+package context
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/common"
+)
+
+// CustomActionCommand runs a named action, e.g. `action = ["revset.set", "mine() & $revset"]`.
+// The placeholders are replaced in the arguments of the action.
+type CustomActionCommand struct {
+	CustomCommandBase
+	CustomCommandGuards
+	Action []string `toml:"action"`
+}
+
+func (c CustomActionCommand) IsApplicableTo(item SelectedItem) bool {
+	return isApplicableTo(item, c.Action)
+}
+
+func (c CustomActionCommand) Description(ctx *MainContext) string {
+	return c.render(ctx.CreateReplacements())
+}
+
+func (c CustomActionCommand) render(replacements map[string]string) string {
+	return fmt.Sprintf("action %s", strings.Join(c.templated(replacements), " "))
+}
+
+func (c CustomActionCommand) templated(replacements map[string]string) []string {
+	return jj.TemplatedArgs(c.Action, maps.Clone(replacements))
+}
+
+func (c CustomActionCommand) Prepare(ctx *MainContext) tea.Cmd {
+	return c.prepare(ctx, c.Name, c.render, func(replacements map[string]string) tea.Cmd {
+		action := c.templated(replacements)
+		if len(action) == 0 {
+			return actionError(fmt.Sprintf("%s: has no action to run", c.Name))
+		}
+		if action[0] == c.Name {
+			return actionError(fmt.Sprintf("%s: runs itself", c.Name))
+		}
+		return common.InvokeAction(action[0], action[1:]...)
+	})
+}
@@ -29,43 +29,74 @@ func (c CustomCommandBase) Binding() key.Binding {
 	)
 }
 
-func LoadCustomCommands(output string) (map[string]CustomCommand, error) {
+// LoadCustomCommands loads the custom commands from each config layer, a command in a later layer replaces
+// the command with the same name in an earlier one
+func LoadCustomCommands(outputs ...string) (map[string]CustomCommand, error) {
+	var registry = make(map[string]CustomCommand)
+	for _, output := range outputs {
+		if err := loadCustomCommands(output, registry); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+func loadCustomCommands(output string, registry map[string]CustomCommand) error {
 	type customCommandsToml struct {
 		RawCustomCommands map[string]toml.Primitive `toml:"custom_commands"`
 	}
 
-	var registry = make(map[string]CustomCommand)
-
 	var metadata toml.MetaData
 	var err error
 
 	var customCommands customCommandsToml
 	metadata, err = toml.Decode(output, &customCommands)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	for name, primitive := range customCommands.RawCustomCommands {
 		var tempMap map[string]interface{}
 		if err := metadata.PrimitiveDecode(primitive, &tempMap); err != nil {
-			return nil, fmt.Errorf("failed to decode custom command %s: %w", name, err)
+			return fmt.Errorf("failed to decode custom command %s: %w", name, err)
 		}
 
-		if _, hasRevset := tempMap["revset"]; hasRevset {
+		if _, hasAction := tempMap["action"]; hasAction {
+			var cmd CustomActionCommand
+			if err := metadata.PrimitiveDecode(primitive, &cmd); err != nil {
+				return fmt.Errorf("failed to decode action command %s: %w", name, err)
+			}
+			cmd.Name = name
+			registry[name] = cmd
+		} else if _, hasPlugin := tempMap["plugin"]; hasPlugin {
+			var cmd CustomPluginCommand
+			if err := metadata.PrimitiveDecode(primitive, &cmd); err != nil {
+				return fmt.Errorf("failed to decode plugin command %s: %w", name, err)
+			}
+			cmd.Name = name
+			registry[name] = cmd
+		} else if _, hasShell := tempMap["shell"]; hasShell {
+			var cmd CustomShellCommand
+			if err := metadata.PrimitiveDecode(primitive, &cmd); err != nil {
+				return fmt.Errorf("failed to decode shell command %s: %w", name, err)
+			}
+			cmd.Name = name
+			registry[name] = cmd
+		} else if _, hasRevset := tempMap["revset"]; hasRevset {
 			var cmd CustomRevsetCommand
 			if err := metadata.PrimitiveDecode(primitive, &cmd); err != nil {
-				return nil, fmt.Errorf("failed to decode revset command %s: %w", name, err)
+				return fmt.Errorf("failed to decode revset command %s: %w", name, err)
 			}
 			cmd.Name = name
 			registry[name] = cmd
 		} else {
 			var cmd CustomRunCommand
 			if err := metadata.PrimitiveDecode(primitive, &cmd); err != nil {
-				return nil, fmt.Errorf("failed to decode run command %s: %w", name, err)
+				return fmt.Errorf("failed to decode run command %s: %w", name, err)
 			}
 			cmd.Name = name
 			registry[name] = cmd
 		}
 	}
-	return registry, nil
+	return nil
 }
@@ -81,3 +81,29 @@ func TestLoad_CustomCommands(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadCustomCommands_Layers(t *testing.T) {
+	user := `
+[custom_commands."show diff"]
+key = ["U"]
+args = ["diff", "-r", "$change_id"]
+
+[custom_commands."log all"]
+key = ["L"]
+revset = "all()"
+`
+	repo := `
+[custom_commands."show diff"]
+key = ["U"]
+args = ["diff", "--git", "-r", "$change_id"]
+
+[custom_commands."run tests"]
+key = ["T"]
+args = ["util", "exec", "--", "make", "test"]
+`
+	registry, err := LoadCustomCommands(user, repo)
+	assert.NoError(t, err)
+	assert.Len(t, registry, 3)
+	assert.Equal(t, []string{"diff", "--git", "-r", "$change_id"}, registry["show diff"].(CustomRunCommand).Args)
+	assert.Equal(t, "all()", registry["log all"].(CustomRevsetCommand).Revset)
+}
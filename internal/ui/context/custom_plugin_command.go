@@ -0,0 +1,152 @@
+package context
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/plugin"
+	"github.com/idursun/jjui/internal/ui/common"
+)
+
+// CustomPluginCommand runs an external plugin. The plugin receives the selected and checked items, the revset
+// and the placeholder values as JSON and replies with the actions jjui should take, see the plugin package
+// for the protocol.
+type CustomPluginCommand struct {
+	CustomCommandBase
+	CustomCommandGuards
+	Plugin []string `toml:"plugin"`
+}
+
+// IsApplicableTo is always true, plugins decide what to do with the item they are sent
+func (c CustomPluginCommand) IsApplicableTo(SelectedItem) bool {
+	return true
+}
+
+func (c CustomPluginCommand) Description(*MainContext) string {
+	return c.render(nil)
+}
+
+func (c CustomPluginCommand) render(map[string]string) string {
+	return fmt.Sprintf("plugin %s", strings.Join(c.Plugin, " "))
+}
+
+func (c CustomPluginCommand) Prepare(ctx *MainContext) tea.Cmd {
+	return c.prepare(ctx, c.Name, c.render, func(replacements map[string]string) tea.Cmd {
+		return c.invoke(ctx, plugin.Request{
+			Event:   plugin.EventInvoke,
+			Context: pluginContext(ctx, replacements),
+		})
+	})
+}
+
+func (c CustomPluginCommand) invoke(ctx *MainContext, request plugin.Request) tea.Cmd {
+	running := func() tea.Msg {
+		return common.CommandRunningMsg(c.render(nil))
+	}
+	return tea.Batch(running, func() tea.Msg {
+		invokeCtx := context.Background()
+		timeout := time.Duration(config.Current.UI.PluginTimeout) * time.Millisecond
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			invokeCtx, cancel = context.WithTimeout(invokeCtx, timeout)
+			defer cancel()
+		}
+		actions, err := plugin.Invoke(invokeCtx, c.Plugin, ctx.Location, request)
+		if errors.Is(err, context.DeadlineExceeded) {
+			return common.CommandCompletedMsg{Err: fmt.Errorf("%s: timed out after %s", c.Name, timeout)}
+		}
+		if err != nil {
+			return common.CommandCompletedMsg{Err: fmt.Errorf("%s: %w", c.Name, err)}
+		}
+		cmds := []tea.Cmd{func() tea.Msg { return common.CommandCompletedMsg{} }}
+		for _, action := range actions {
+			cmds = append(cmds, c.apply(ctx, request, action))
+		}
+		return tea.Sequence(cmds...)()
+	})
+}
+
+// apply turns an action of the plugin's reply into the command that carries it out
+func (c CustomPluginCommand) apply(ctx *MainContext, request plugin.Request, action plugin.Action) tea.Cmd {
+	switch action.Action {
+	case plugin.ActionRun:
+		return ctx.RunCommand(action.Args, common.Refresh)
+	case plugin.ActionInvoke:
+		return common.InvokeAction(action.Name, action.Args...)
+	case plugin.ActionSetRevset:
+		return common.UpdateRevSet(action.Revset)
+	case plugin.ActionFlash:
+		return func() tea.Msg {
+			if action.Error {
+				return common.CommandCompletedMsg{Err: fmt.Errorf("%s: %s", c.Name, action.Message)}
+			}
+			return common.CommandCompletedMsg{Output: action.Message}
+		}
+	case plugin.ActionShowDiff:
+		return func() tea.Msg {
+			return common.ShowDiffMsg(action.Text)
+		}
+	case plugin.ActionMenu:
+		title := action.Title
+		if title == "" {
+			title = c.Name
+		}
+		items := make([]common.MenuItem, 0, len(action.Items))
+		for _, item := range action.Items {
+			selected := request
+			selected.Event = plugin.EventMenuSelect
+			selected.MenuItem = item.Id
+			label := item.Label
+			if label == "" {
+				label = item.Id
+			}
+			items = append(items, common.MenuItem{
+				Label:       label,
+				Description: item.Description,
+				Key:         item.Key,
+				Command:     c.invoke(ctx, selected),
+			})
+		}
+		return func() tea.Msg {
+			return common.ShowMenuMsg{Title: title, Items: items}
+		}
+	}
+	return nil
+}
+
+// pluginContext describes the state of jjui to a plugin
+func pluginContext(ctx *MainContext, replacements map[string]string) plugin.Context {
+	pc := plugin.Context{
+		Location:     ctx.Location,
+		Revset:       ctx.CurrentRevset,
+		Checked:      []plugin.Item{},
+		Replacements: maps.Clone(replacements),
+	}
+	if selected, ok := pluginItem(ctx.SelectedItem); ok {
+		pc.Selected = &selected
+	}
+	for _, checked := range ctx.CheckedItems {
+		if item, ok := pluginItem(checked); ok {
+			pc.Checked = append(pc.Checked, item)
+		}
+	}
+	return pc
+}
+
+func pluginItem(item SelectedItem) (plugin.Item, bool) {
+	switch item := item.(type) {
+	case SelectedRevision:
+		return plugin.Item{Type: plugin.ItemRevision, ChangeId: item.ChangeId, CommitId: item.CommitId}, true
+	case SelectedFile:
+		return plugin.Item{Type: plugin.ItemFile, ChangeId: item.ChangeId, CommitId: item.CommitId, File: item.File}, true
+	case SelectedOperation:
+		return plugin.Item{Type: plugin.ItemOperation, OperationId: item.OperationId}, true
+	}
+	return plugin.Item{}, false
+}
@@ -2,6 +2,7 @@ package context
 
 import (
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
 
@@ -13,15 +14,21 @@ import (
 
 type CustomRunCommand struct {
 	CustomCommandBase
+	CustomCommandGuards
 	Args []string          `toml:"args"`
 	Show config.ShowOption `toml:"show"`
 }
 
 func (c CustomRunCommand) IsApplicableTo(item SelectedItem) bool {
-	hasChangeIdPlaceholder := slices.ContainsFunc(c.Args, func(s string) bool { return strings.Contains(s, jj.ChangeIdPlaceholder) })
-	hasCommitIdPlaceholder := slices.ContainsFunc(c.Args, func(s string) bool { return strings.Contains(s, jj.CommitIdPlaceholder) })
-	hasFilePlaceholder := slices.ContainsFunc(c.Args, func(s string) bool { return strings.Contains(s, jj.FilePlaceholder) })
-	hasOperationIdPlaceholder := slices.ContainsFunc(c.Args, func(s string) bool { return strings.Contains(s, jj.OperationIdPlaceholder) })
+	return isApplicableTo(item, c.Args)
+}
+
+// isApplicableTo decides by the placeholders used in the texts whether a command can run for the item
+func isApplicableTo(item SelectedItem, texts []string) bool {
+	hasChangeIdPlaceholder := usesPlaceholder(texts, jj.ChangeIdPlaceholder)
+	hasCommitIdPlaceholder := usesPlaceholder(texts, jj.CommitIdPlaceholder)
+	hasFilePlaceholder := usesPlaceholder(texts, jj.FilePlaceholder)
+	hasOperationIdPlaceholder := usesPlaceholder(texts, jj.OperationIdPlaceholder)
 	if !hasChangeIdPlaceholder && !hasFilePlaceholder && !hasOperationIdPlaceholder && !hasCommitIdPlaceholder {
 		// If no placeholders are used, the command is applicable to any item
 		return true
@@ -39,22 +46,99 @@ func (c CustomRunCommand) IsApplicableTo(item SelectedItem) bool {
 	}
 }
 
+// usesPlaceholder reports whether any of the texts uses the placeholder, shell commands can also refer to
+// it as ${change_id}
+func usesPlaceholder(texts []string, placeholder string) bool {
+	braced := "${" + strings.TrimPrefix(placeholder, "$") + "}"
+	return slices.ContainsFunc(texts, func(s string) bool {
+		return strings.Contains(s, placeholder) || strings.Contains(s, braced)
+	})
+}
+
 func (c CustomRunCommand) Description(ctx *MainContext) string {
-	args := jj.TemplatedArgs(c.Args, ctx.CreateReplacements())
+	return c.render(ctx.CreateReplacements())
+}
+
+func (c CustomRunCommand) render(replacements map[string]string) string {
+	args := jj.TemplatedArgs(c.Args, maps.Clone(replacements))
 	return fmt.Sprintf("jj %s", strings.Join(args, " "))
 }
 
 func (c CustomRunCommand) Prepare(ctx *MainContext) tea.Cmd {
+	return c.prepare(ctx, c.Name, c.render, func(replacements map[string]string) tea.Cmd {
+		return c.run(ctx, replacements)
+	})
+}
+
+// CustomCommandGuards are the checks that run before a custom command
+type CustomCommandGuards struct {
+	// When is a revset the selected revision has to be in for the command to run
+	When string `toml:"when"`
+	// Prompt asks for the value of $input before running the command
+	Prompt string `toml:"prompt"`
+	// Confirm shows the command and asks for confirmation before running it
+	Confirm bool `toml:"confirm"`
+}
+
+func (g CustomCommandGuards) prepare(ctx *MainContext, name string, render func(map[string]string) string, run func(map[string]string) tea.Cmd) tea.Cmd {
 	replacements := ctx.CreateReplacements()
+	confirm := func() tea.Cmd {
+		if !g.Confirm {
+			return run(replacements)
+		}
+		return func() tea.Msg {
+			return common.ConfirmMsg{
+				Title:     name,
+				Message:   render(replacements),
+				Confirmed: run(replacements),
+			}
+		}
+	}
+	next := func() tea.Cmd {
+		if g.Prompt == "" {
+			return confirm()
+		}
+		return func() tea.Msg {
+			return common.PromptMsg{
+				Prompt: g.Prompt,
+				Accept: func(input string) tea.Cmd {
+					replacements[jj.InputPlaceholder] = input
+					return confirm()
+				},
+			}
+		}
+	}
+	if g.When == "" {
+		return next()
+	}
+	return func() tea.Msg {
+		changeId := replacements[jj.ChangeIdPlaceholder]
+		if changeId == "" {
+			return common.CommandCompletedMsg{Err: fmt.Errorf("%s: needs a selected revision to check `%s`", name, g.When)}
+		}
+		when := jj.TemplatedArgs([]string{g.When}, maps.Clone(replacements))[0]
+		output, err := ctx.RunCommandImmediate(jj.MatchingRevision(changeId, when))
+		if err != nil {
+			return common.CommandCompletedMsg{Output: string(output), Err: err}
+		}
+		if strings.TrimSpace(string(output)) == "" {
+			return common.CommandCompletedMsg{Err: fmt.Errorf("%s: the selected revision is not in `%s`", name, when)}
+		}
+		return next()()
+	}
+}
+
+func (c CustomRunCommand) run(ctx *MainContext, replacements map[string]string) tea.Cmd {
+	args := jj.TemplatedArgs(c.Args, maps.Clone(replacements))
 	switch c.Show {
 	case config.ShowOptionDiff:
 		return func() tea.Msg {
-			output, _ := ctx.RunCommandImmediate(jj.TemplatedArgs(c.Args, replacements))
+			output, _ := ctx.RunCommandImmediate(args)
 			return common.ShowDiffMsg(output)
 		}
 	case config.ShowOptionInteractive:
-		return ctx.RunInteractiveCommand(jj.TemplatedArgs(c.Args, replacements), common.Refresh)
+		return ctx.RunInteractiveCommand(args, common.Refresh)
 	default:
-		return ctx.RunCommand(jj.TemplatedArgs(c.Args, replacements), common.Refresh)
+		return ctx.RunCommand(args, common.Refresh)
 	}
 }
@@ -0,0 +1,102 @@
+package context
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/ui/common"
+)
+
+// CustomShellCommand runs a command line through `$SHELL -c`. The placeholders are not substituted in the
+// command line, they are exported as environment variables instead (e.g. $change_id), the same way the
+// exec shell prompt does.
+type CustomShellCommand struct {
+	CustomCommandBase
+	CustomCommandGuards
+	Shell string            `toml:"shell"`
+	Show  config.ShowOption `toml:"show"`
+}
+
+func (c CustomShellCommand) IsApplicableTo(item SelectedItem) bool {
+	return isApplicableTo(item, []string{c.Shell})
+}
+
+func (c CustomShellCommand) Description(*MainContext) string {
+	return c.render(nil)
+}
+
+func (c CustomShellCommand) render(map[string]string) string {
+	return fmt.Sprintf("$ %s", c.Shell)
+}
+
+func (c CustomShellCommand) Prepare(ctx *MainContext) tea.Cmd {
+	return c.prepare(ctx, c.Name, c.render, func(replacements map[string]string) tea.Cmd {
+		return c.run(ctx, replacements)
+	})
+}
+
+func (c CustomShellCommand) run(ctx *MainContext, replacements map[string]string) tea.Cmd {
+	cmd := ShellCommand(context.Background(), ctx.Location, c.Shell, replacements)
+	running := func() tea.Msg {
+		return common.CommandRunningMsg(c.render(replacements))
+	}
+	if c.Show == config.ShowOptionInteractive {
+		return tea.Batch(running, tea.ExecProcess(cmd, func(err error) tea.Msg {
+			return tea.Batch(common.Refresh, func() tea.Msg {
+				return common.CommandCompletedMsg{Err: err}
+			})()
+		}))
+	}
+	return tea.Batch(running, func() tea.Msg {
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			var exitError *exec.ExitError
+			if errors.As(err, &exitError) {
+				err = fmt.Errorf("%s exited with %d", c.Name, exitError.ExitCode())
+			}
+			return common.CommandCompletedMsg{Output: string(output), Err: err}
+		}
+		completed := func() tea.Msg {
+			return common.CommandCompletedMsg{}
+		}
+		switch c.Show {
+		case config.ShowOptionDiff:
+			return tea.BatchMsg{completed, func() tea.Msg { return common.ShowDiffMsg(output) }}
+		case config.ShowOptionPreview:
+			return tea.BatchMsg{completed, func() tea.Msg { return common.ShowPreviewContentMsg(output) }}
+		case config.ShowOptionFlash:
+			return common.CommandCompletedMsg{Output: string(output)}
+		default:
+			return tea.BatchMsg{func() tea.Msg { return common.CommandCompletedMsg{Output: string(output)} }, common.Refresh}
+		}
+	})
+}
+
+// ShellCommand builds a `$SHELL -c` command with the replacements exported as environment variables,
+// the command is killed when ctx is cancelled
+func ShellCommand(ctx context.Context, location string, line string, replacements map[string]string) *exec.Cmd {
+	program := os.Getenv("SHELL")
+	if len(program) == 0 {
+		program = "sh"
+	}
+	cmd := exec.CommandContext(ctx, program, "-c", line)
+	cmd.Dir = location
+	cmd.Env = Environ(replacements)
+	return cmd
+}
+
+// Environ extends the current environment with the replacements so that the programs run by jjui can read
+// them, e.g. $change_id is exported as change_id
+func Environ(replacements map[string]string) []string {
+	env := os.Environ()
+	for k, v := range replacements {
+		env = append(env, strings.TrimPrefix(k, "$")+"="+v)
+	}
+	return env
+}
@@ -0,0 +1,131 @@
+package context
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeyMsgs turns the name of a key like "enter" or "ctrl+s" into its key message and any other text
+// into a key message per rune
+func KeyMsgs(s string) []tea.KeyMsg {
+	if k, ok := keyNames[s]; ok {
+		return []tea.KeyMsg{tea.KeyMsg(k)}
+	}
+	var msgs []tea.KeyMsg
+	for _, r := range s {
+		msgs = append(msgs, tea.KeyMsg{
+			Type:  tea.KeyRunes,
+			Runes: []rune{r},
+		})
+	}
+	return msgs
+}
+
+// From bubbletea's key.go. So that we can identify by their string.
+// Notable Exception: tea.KeyRunes. Because we create them.
+var keyTypes = []tea.KeyType{
+	// Control keys.
+	tea.KeyTab,
+	tea.KeyEnter,
+	tea.KeyEsc,
+	tea.KeyBackspace,
+
+	tea.KeyCtrlAt,
+	tea.KeyCtrlA,
+	tea.KeyCtrlB,
+	tea.KeyCtrlC,
+	tea.KeyCtrlD,
+	tea.KeyCtrlE,
+	tea.KeyCtrlF,
+	tea.KeyCtrlG,
+	tea.KeyCtrlH,
+	tea.KeyCtrlJ,
+	tea.KeyCtrlK,
+	tea.KeyCtrlL,
+	tea.KeyCtrlN,
+	tea.KeyCtrlO,
+	tea.KeyCtrlP,
+	tea.KeyCtrlQ,
+	tea.KeyCtrlR,
+	tea.KeyCtrlS,
+	tea.KeyCtrlT,
+	tea.KeyCtrlU,
+	tea.KeyCtrlV,
+	tea.KeyCtrlW,
+	tea.KeyCtrlX,
+	tea.KeyCtrlY,
+	tea.KeyCtrlZ,
+
+	tea.KeyCtrlCloseBracket,
+	tea.KeyCtrlCaret,
+	tea.KeyCtrlUnderscore,
+	tea.KeyCtrlBackslash,
+
+	// Other keys.
+	tea.KeyUp,
+	tea.KeyDown,
+	tea.KeyRight,
+	tea.KeySpace,
+	tea.KeyLeft,
+	tea.KeyShiftTab,
+	tea.KeyHome,
+	tea.KeyEnd,
+	tea.KeyCtrlHome,
+	tea.KeyCtrlEnd,
+	tea.KeyShiftHome,
+	tea.KeyShiftEnd,
+	tea.KeyCtrlShiftHome,
+	tea.KeyCtrlShiftEnd,
+	tea.KeyPgUp,
+	tea.KeyPgDown,
+	tea.KeyCtrlPgUp,
+	tea.KeyCtrlPgDown,
+	tea.KeyDelete,
+	tea.KeyInsert,
+	tea.KeyCtrlUp,
+	tea.KeyCtrlDown,
+	tea.KeyCtrlRight,
+	tea.KeyCtrlLeft,
+	tea.KeyShiftUp,
+	tea.KeyShiftDown,
+	tea.KeyShiftRight,
+	tea.KeyShiftLeft,
+	tea.KeyCtrlShiftUp,
+	tea.KeyCtrlShiftDown,
+	tea.KeyCtrlShiftLeft,
+	tea.KeyCtrlShiftRight,
+	tea.KeyF1,
+	tea.KeyF2,
+	tea.KeyF3,
+	tea.KeyF4,
+	tea.KeyF5,
+	tea.KeyF6,
+	tea.KeyF7,
+	tea.KeyF8,
+	tea.KeyF9,
+	tea.KeyF10,
+	tea.KeyF11,
+	tea.KeyF12,
+	tea.KeyF13,
+	tea.KeyF14,
+	tea.KeyF15,
+	tea.KeyF16,
+	tea.KeyF17,
+	tea.KeyF18,
+	tea.KeyF19,
+	tea.KeyF20,
+}
+
+func keysFromTypes() map[string]tea.Key {
+	m := map[string]tea.Key{}
+	set := func(t tea.KeyType) {
+		m[t.String()] = tea.Key{
+			Type: t,
+		}
+	}
+	for _, t := range keyTypes {
+		set(t)
+	}
+	return m
+}
+
+var keyNames = keysFromTypes()
@@ -10,40 +10,48 @@ import (
 type LeaderMap = map[string]*Leader
 
 type Leader struct {
-	Bind    *key.Binding
-	Send    []string
+	Bind *key.Binding
+	Send []string
+	// Action is the name of an action and its arguments, it is run instead of sending keys
+	Action  []string
 	Context []string
 	Nest    LeaderMap
 }
 
-func LoadLeader(content string) (LeaderMap, error) {
+// LoadLeader loads the leader keys from each config layer, an entry in a later layer replaces
+// the entry with the same key sequence in an earlier one
+func LoadLeader(contents ...string) (LeaderMap, error) {
 	type leaderTomlEntry struct {
 		Help    string
 		Send    []string
+		Action  []string
 		Context []string
 	}
 	type leaderToml struct {
 		Leader map[string]leaderTomlEntry
 	}
-	dec := leaderToml{}
-	_, err := toml.Decode(content, &dec)
-	if err != nil {
-		return nil, err
-	}
 	res := LeaderMap{}
-	for name, v := range dec.Leader {
-		ks := strings.Split(name, "")
-		at := res
-		for i, k := range ks {
-			m := checkExists(at, k)
-			if i == len(ks)-1 {
-				m.Send = v.Send
-				m.Context = v.Context
-				if len(v.Help) > 0 {
-					m.Bind.SetHelp(k, v.Help)
+	for _, content := range contents {
+		dec := leaderToml{}
+		_, err := toml.Decode(content, &dec)
+		if err != nil {
+			return nil, err
+		}
+		for name, v := range dec.Leader {
+			ks := strings.Split(name, "")
+			at := res
+			for i, k := range ks {
+				m := checkExists(at, k)
+				if i == len(ks)-1 {
+					m.Send = v.Send
+					m.Action = v.Action
+					m.Context = v.Context
+					if len(v.Help) > 0 {
+						m.Bind.SetHelp(k, v.Help)
+					}
 				}
+				at = m.Nest
 			}
-			at = m.Nest
 		}
 	}
 	return res, nil
@@ -79,3 +79,43 @@ func TestLoadLeader(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadLeader_Layers(t *testing.T) {
+	repo := `
+[leader.M]
+help = "Set bookmark trunk and push"
+send = ["b/move trunk", "down", "enter", "gp", "enter"]
+
+[leader.gt]
+help = "Git Fetch Trunk"
+send = ["g/fetch -b trunk", "down", "enter"]
+`
+	lm, err := LoadLeader(exampleLeaderToml, repo)
+	if err != nil {
+		t.Fatalf("LoadLeader failed: %v", err)
+	}
+	if got := lm["M"].Bind.Help().Desc; got != "Set bookmark trunk and push" {
+		t.Errorf("leader.M was not overridden: got %q", got)
+	}
+	g := lm["g"]
+	if g.Bind.Help().Desc != "Git" {
+		t.Errorf("leader.g help mismatch: got %q", g.Bind.Help().Desc)
+	}
+	if g.Nest["f"] == nil || g.Nest["t"] == nil {
+		t.Errorf("leader.g should contain entries from both layers: got %v", g.Nest)
+	}
+}
+
+func TestLoadLeader_Action(t *testing.T) {
+	lm, err := LoadLeader(`
+[leader.p]
+help = "Push"
+action = ["git.push"]
+`)
+	if err != nil {
+		t.Fatalf("LoadLeader failed: %v", err)
+	}
+	if got := lm["p"].Action; len(got) != 1 || got[0] != "git.push" {
+		t.Errorf("expected the git.push action, got %v", got)
+	}
+}
@@ -56,6 +56,7 @@ type MainContext struct {
 	CommandRunner
 	SelectedItem   SelectedItem   // Single item where cursor is hover.
 	CheckedItems   []SelectedItem // Items checked ✓ by the user.
+	AdjacentItems  []SelectedItem // Items right above and below the selected item, their previews are prefetched.
 	Location       string
 	CustomCommands map[string]CustomCommand
 	Leader         LeaderMap
@@ -63,15 +64,25 @@ type MainContext struct {
 	DefaultRevset  string
 	CurrentRevset  string
 	Histories      *config.Histories
+	// DarkBackground selects between the dark and light themes
+	DarkBackground bool
+	// ConfigOverrides re-applies the command line flags whenever the configuration is (re)loaded
+	ConfigOverrides func(c *config.Config)
+	// Recorder keeps the jj invocations of the session, it is nil when they are not recorded
+	Recorder *CommandRecorder
+	queue    *CommandQueue
 }
 
 func NewAppContext(location string) *MainContext {
+	recorder := NewCommandRecorder()
 	m := &MainContext{
 		CommandRunner: &MainCommandRunner{
 			Location: location,
+			Recorder: recorder,
 		},
 		Location:  location,
 		Histories: config.NewHistories(),
+		Recorder:  recorder,
 	}
 
 	m.JJConfig = &config.JJConfig{}
@@ -81,6 +92,23 @@ func NewAppContext(location string) *MainContext {
 	return m
 }
 
+// Query runs `jj <args>` on the command queue and turns its output into the message returned by result
+func (ctx *MainContext) Query(args []string, result func(output []byte, err error) tea.Msg) tea.Cmd {
+	return ctx.commandQueue().Query(args, result)
+}
+
+// QueueDepth is the number of queries waiting or running on the command queue
+func (ctx *MainContext) QueueDepth() int {
+	return ctx.commandQueue().Depth()
+}
+
+func (ctx *MainContext) commandQueue() *CommandQueue {
+	if ctx.queue == nil {
+		ctx.queue = NewCommandQueue(ctx.CommandRunner, MaxConcurrentQueries)
+	}
+	return ctx.queue
+}
+
 func (ctx *MainContext) ClearCheckedItems(ofType reflect.Type) {
 	ctx.CheckedItems = slices.DeleteFunc(ctx.CheckedItems, func(i SelectedItem) bool {
 		return ofType == nil || ofType == reflect.TypeOf(i)
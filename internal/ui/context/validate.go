@@ -0,0 +1,216 @@
+package context
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/common"
+)
+
+var knownPlaceholders = []string{
+	jj.ChangeIdPlaceholder,
+	jj.CommitIdPlaceholder,
+	jj.FilePlaceholder,
+	jj.OperationIdPlaceholder,
+	jj.RevsetPlaceholder,
+	jj.CheckedFilesPlaceholder,
+	jj.CheckedCommitIdsPlaceholder,
+	jj.InputPlaceholder,
+}
+
+// placeholders are lower case so that environment variables like $HOME are left alone
+var placeholderRegex = regexp.MustCompile(`\$\{?([a-z_]+)`)
+
+// configIssue is a problem with an entry of a config table, e.g. a custom command or a leader sequence
+type configIssue struct {
+	table   string
+	name    string
+	message string
+}
+
+func (i configIssue) String() string {
+	return fmt.Sprintf("%s.%s: %s", i.table, i.name, i.message)
+}
+
+// ValidateLayers validates the config layers and prefixes the problems with the layer they were found in.
+// The settings of each layer are checked on their own while the custom commands and the leader entries are
+// checked after the layers are merged since they can refer to each other across layers. Their problems
+// are reported in the last layer defining the entry.
+func ValidateLayers(layers []config.Layer) ([]string, error) {
+	var issues []string
+	var contents []string
+	for _, layer := range layers {
+		layerIssues, err := validateSettings(layer.Data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", layer.Source, err)
+		}
+		if _, err := LoadCustomCommands(layer.Data); err != nil {
+			layerIssues = append(layerIssues, err.Error())
+		}
+		if _, err := LoadLeader(layer.Data); err != nil {
+			layerIssues = append(layerIssues, err.Error())
+		}
+		for _, issue := range layerIssues {
+			issues = append(issues, fmt.Sprintf("%s: %s", layer.Source, issue))
+		}
+		contents = append(contents, layer.Data)
+	}
+
+	c, err := config.LoadLayers(layers)
+	if err != nil {
+		return nil, err
+	}
+	// the layers which fail to load are already reported above
+	customCommands, _ := LoadCustomCommands(contents...)
+	leader, _ := LoadLeader(contents...)
+	entryIssues := append(validateCustomCommands(customCommands), validateLeader(leader, "", c.GetKeyMap().Cancel.Keys(), customCommands)...)
+	for _, issue := range entryIssues {
+		if layer, ok := definingLayer(layers, issue.table, issue.name); ok {
+			issues = append(issues, fmt.Sprintf("%s: %s", layer.Source, issue))
+		} else {
+			issues = append(issues, issue.String())
+		}
+	}
+	return issues, nil
+}
+
+// definingLayer returns the last layer which defines the named entry of the table. Leader entries are
+// also defined by the longer sequences starting with the name.
+func definingLayer(layers []config.Layer, table string, name string) (config.Layer, bool) {
+	for _, layer := range slices.Backward(layers) {
+		var values map[string]map[string]any
+		if _, err := toml.Decode(layer.Data, &values); err != nil {
+			continue
+		}
+		for entry := range values[table] {
+			if entry == name || (table == "leader" && strings.HasPrefix(entry, name)) {
+				return layer, true
+			}
+		}
+	}
+	return config.Layer{}, false
+}
+
+// validateSettings checks the keys, colors and themes of config.toml
+func validateSettings(data string) ([]string, error) {
+	issues, err := config.Validate(data)
+	if err != nil {
+		return nil, err
+	}
+	c, err := config.LoadConfig(data)
+	if err != nil {
+		return nil, err
+	}
+	issues = append(issues, validateColors("ui.colors", c.UI.Colors)...)
+	for _, name := range slices.Compact([]string{c.UI.Theme.Dark, c.UI.Theme.Light}) {
+		if name == "" {
+			continue
+		}
+		theme, err := config.LoadTheme(name, nil)
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("theme %s: %v", name, err))
+			continue
+		}
+		issues = append(issues, validateColors("theme "+name, theme)...)
+	}
+	return issues, nil
+}
+
+func validateColors(source string, colors map[string]config.Color) []string {
+	var issues []string
+	for _, name := range sortedKeys(colors) {
+		color := colors[name]
+		for _, value := range []string{color.Fg, color.Bg} {
+			if !common.IsValidColor(value) {
+				issues = append(issues, fmt.Sprintf("%s: %q has an invalid color %q", source, name, value))
+			}
+		}
+	}
+	return issues
+}
+
+func validateCustomCommands(commands map[string]CustomCommand) []configIssue {
+	var issues []configIssue
+	for _, name := range sortedKeys(commands) {
+		var texts []string
+		switch command := commands[name].(type) {
+		case CustomRunCommand:
+			texts = append(slices.Clone(command.Args), command.When)
+			if command.Prompt == "" && slices.ContainsFunc(command.Args, func(arg string) bool { return strings.Contains(arg, jj.InputPlaceholder) }) {
+				issues = append(issues, configIssue{"custom_commands", name, fmt.Sprintf("uses %s without a prompt", jj.InputPlaceholder)})
+			}
+		case CustomShellCommand:
+			// the shell line is not checked, it can use any variable of the shell like $HOME
+			texts = []string{command.When}
+			if command.Prompt == "" && usesPlaceholder([]string{command.Shell}, jj.InputPlaceholder) {
+				issues = append(issues, configIssue{"custom_commands", name, fmt.Sprintf("uses %s without a prompt", jj.InputPlaceholder)})
+			}
+		case CustomRevsetCommand:
+			texts = []string{command.Revset}
+		case CustomActionCommand:
+			texts = append(slices.Clone(command.Action), command.When)
+			if len(command.Action) == 0 {
+				issues = append(issues, configIssue{"custom_commands", name, "action is empty"})
+			} else if !IsKnownAction(command.Action[0], commands) {
+				issues = append(issues, configIssue{"custom_commands", name, fmt.Sprintf("unknown action %q", command.Action[0])})
+			}
+		case CustomPluginCommand:
+			texts = []string{command.When}
+			if len(command.Plugin) == 0 {
+				issues = append(issues, configIssue{"custom_commands", name, "plugin has no command to run"})
+			}
+		}
+		for _, text := range texts {
+			for _, match := range placeholderRegex.FindAllStringSubmatch(text, -1) {
+				placeholder := "$" + match[1]
+				if !slices.Contains(knownPlaceholders, placeholder) {
+					issues = append(issues, configIssue{"custom_commands", name, "unknown placeholder " + placeholder})
+				}
+			}
+		}
+	}
+	return issues
+}
+
+// validateLeader reports the leader entries that can never be triggered
+func validateLeader(leader LeaderMap, prefix string, cancelKeys []string, customCommands map[string]CustomCommand) []configIssue {
+	var issues []configIssue
+	for _, k := range sortedKeys(leader) {
+		entry := leader[k]
+		sequence := prefix + k
+		switch {
+		case slices.Contains(cancelKeys, k):
+			issues = append(issues, configIssue{"leader", sequence, fmt.Sprintf("%q closes the leader menu and can never be reached", k)})
+		case len(entry.Nest) > 0 && (len(entry.Send) > 0 || len(entry.Action) > 0):
+			issues = append(issues, configIssue{"leader", sequence, fmt.Sprintf("send is never used because other entries start with %q", sequence)})
+		case len(entry.Nest) == 0 && len(entry.Send) == 0 && len(entry.Action) == 0:
+			issues = append(issues, configIssue{"leader", sequence, "has nothing to send"})
+		case len(entry.Send) > 0 && len(entry.Action) > 0:
+			issues = append(issues, configIssue{"leader", sequence, "send is never used because the entry runs an action"})
+		case len(entry.Action) > 0 && !IsKnownAction(entry.Action[0], customCommands):
+			issues = append(issues, configIssue{"leader", sequence, fmt.Sprintf("unknown action %q", entry.Action[0])})
+		}
+		for _, c := range entry.Context {
+			if !slices.Contains(knownPlaceholders, c) {
+				issues = append(issues, configIssue{"leader", sequence, fmt.Sprintf("context %s is never available so the entry is never shown", c)})
+			}
+		}
+		issues = append(issues, validateLeader(entry.Nest, sequence, cancelKeys, customCommands)...)
+	}
+	return issues
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,170 @@
+package context
+
+import (
+	"testing"
+
+	"github.com/idursun/jjui/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validateUserConfig validates data as the only layer on top of the defaults
+func validateUserConfig(t *testing.T, data string) []string {
+	issues, err := ValidateLayers([]config.Layer{{Name: config.UserLayer, Source: "config.toml", Data: data}})
+	require.NoError(t, err)
+	return issues
+}
+
+func TestValidateLayers(t *testing.T) {
+	issues := validateUserConfig(t, `
+[keys]
+cancel = ["esc", "z"]
+
+[ui.colors]
+"revisions selected" = { fg = "not-a-color" }
+
+[custom_commands."show file"]
+args = ["diff", "-r", "$change_id", "$fil"]
+
+[leader.g]
+help = "Git"
+send = ["g"]
+
+[leader.gp]
+help = "Push"
+send = ["gp", "enter"]
+
+[leader.x]
+help = "Nothing"
+
+[leader.z]
+help = "Unreachable"
+send = ["q"]
+`)
+	assert.ElementsMatch(t, []string{
+		`config.toml: ui.colors: "revisions selected" has an invalid color "not-a-color"`,
+		`config.toml: custom_commands.show file: unknown placeholder $fil`,
+		`config.toml: leader.z: "z" closes the leader menu and can never be reached`,
+		`config.toml: leader.g: send is never used because other entries start with "g"`,
+		`config.toml: leader.x: has nothing to send`,
+	}, issues)
+}
+
+func TestValidateLayers_Valid(t *testing.T) {
+	issues := validateUserConfig(t, `
+[custom_commands."show file"]
+args = ["diff", "-r", "$change_id", "$file"]
+`)
+	assert.Empty(t, issues)
+}
+
+func TestValidateLayers_InputWithoutPrompt(t *testing.T) {
+	issues := validateUserConfig(t, `
+[custom_commands."create bookmark"]
+args = ["bookmark", "create", "$input", "-r", "$change_id"]
+`)
+	assert.Equal(t, []string{"config.toml: custom_commands.create bookmark: uses $input without a prompt"}, issues)
+}
+
+func TestValidateLayers_ShellCommand(t *testing.T) {
+	issues := validateUserConfig(t, `
+[custom_commands."open"]
+shell = "$EDITOR $HOME/${input}"
+when = "$change_i & mine()"
+
+[custom_commands."copy id"]
+shell = "echo $change_id | pbcopy"
+`)
+	assert.ElementsMatch(t, []string{
+		"config.toml: custom_commands.open: uses $input without a prompt",
+		"config.toml: custom_commands.open: unknown placeholder $change_i",
+	}, issues)
+}
+
+func TestValidateLayers_EmptyPlugin(t *testing.T) {
+	issues := validateUserConfig(t, `
+[custom_commands."review"]
+plugin = []
+when = "$change_i & mine()"
+`)
+	assert.ElementsMatch(t, []string{
+		"config.toml: custom_commands.review: plugin has no command to run",
+		"config.toml: custom_commands.review: unknown placeholder $change_i",
+	}, issues)
+}
+
+func TestValidateLayers_UnknownActions(t *testing.T) {
+	issues := validateUserConfig(t, `
+[custom_commands."children"]
+action = ["revset.sett", "$change_id::"]
+
+[custom_commands."push"]
+action = ["git.push"]
+
+[leader.p]
+action = ["push"]
+
+[leader.x]
+action = ["revisions.nope"]
+send = ["x"]
+`)
+	assert.ElementsMatch(t, []string{
+		`config.toml: custom_commands.children: unknown action "revset.sett"`,
+		`config.toml: leader.x: send is never used because the entry runs an action`,
+	}, issues)
+}
+
+func TestValidateLayers_ReferencesAcrossLayers(t *testing.T) {
+	user := config.Layer{Name: config.UserLayer, Source: "user.toml", Data: `
+[custom_commands."push"]
+action = ["git.push"]
+
+[ui.colors]
+"revisions selected" = { fg = "not-a-color" }
+`}
+	repo := config.Layer{Name: config.RepoLayer, Source: "jjui.toml", Data: `
+[custom_commands."push trunk"]
+action = ["push"]
+
+[leader.p]
+action = ["push"]
+
+[leader.x]
+action = ["nope"]
+`}
+	issues, err := ValidateLayers([]config.Layer{user, repo})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		`user.toml: ui.colors: "revisions selected" has an invalid color "not-a-color"`,
+		`jjui.toml: leader.x: unknown action "nope"`,
+	}, issues)
+}
+
+func TestValidateLayers_ProblemIntroducedByRepoLayer(t *testing.T) {
+	user := config.Layer{Name: config.UserLayer, Source: "user.toml", Data: `
+[keys]
+cancel = ["esc", "z"]
+
+[custom_commands."create bookmark"]
+args = ["bookmark", "create", "$input", "-r", "$change_id"]
+prompt = "Name"
+`}
+	repo := config.Layer{Name: config.RepoLayer, Source: "jjui.toml", Data: `
+[custom_commands."create bookmark"]
+args = ["bookmark", "create", "$input", "-r", "$change_id"]
+
+[leader.z]
+help = "Unreachable"
+send = ["q"]
+`}
+	issues, err := ValidateLayers([]config.Layer{user})
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+
+	issues, err = ValidateLayers([]config.Layer{user, repo})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		`jjui.toml: custom_commands.create bookmark: uses $input without a prompt`,
+		`jjui.toml: leader.z: "z" closes the leader menu and can never be reached`,
+	}, issues)
+}
@@ -0,0 +1,48 @@
+package customcommands
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/confirmation"
+)
+
+// ConfirmModel asks for confirmation before running a custom command
+type ConfirmModel struct {
+	confirmation *confirmation.Model
+}
+
+func (m ConfirmModel) ShortHelp() []key.Binding {
+	return m.confirmation.ShortHelp()
+}
+
+func (m ConfirmModel) FullHelp() [][]key.Binding {
+	return [][]key.Binding{m.ShortHelp()}
+}
+
+func (m ConfirmModel) Init() tea.Cmd {
+	return m.confirmation.Init()
+}
+
+func (m ConfirmModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.confirmation, cmd = m.confirmation.Update(msg)
+	return m, cmd
+}
+
+func (m ConfirmModel) View() string {
+	return m.confirmation.View()
+}
+
+func NewConfirmModel(msg common.ConfirmMsg) ConfirmModel {
+	command := lipgloss.NewStyle().PaddingBottom(1).Render(msg.Message)
+	model := confirmation.New(
+		[]string{command, "Are you sure you want to run " + msg.Title + "?"},
+		confirmation.WithStylePrefix("custom_commands"),
+		confirmation.WithOption("Yes", tea.Batch(msg.Confirmed, common.Close), key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "yes"))),
+		confirmation.WithOption("No", common.Close, key.NewBinding(key.WithKeys("n", "esc"), key.WithHelp("n/esc", "no"))),
+	)
+	model.Styles.Border = common.DefaultPalette.GetBorder("custom_commands border", lipgloss.NormalBorder()).Padding(1)
+	return ConfirmModel{confirmation: model}
+}
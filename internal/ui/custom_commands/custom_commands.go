@@ -119,9 +119,26 @@ func NewModel(ctx *context.MainContext, width int, height int) *Model {
 			items = append(items, item{name: name, desc: command.Description(ctx), command: cmd, key: command.Binding()})
 		}
 	}
+	return newModel(ctx, "Custom Commands", items, width, height)
+}
+
+// NewMenuModel shows the items of a menu sent by a plugin
+func NewMenuModel(ctx *context.MainContext, msg common.ShowMenuMsg, width int, height int) *Model {
+	var items []list.Item
+	for _, menuItem := range msg.Items {
+		binding := key.NewBinding(key.WithDisabled())
+		if menuItem.Key != "" {
+			binding = key.NewBinding(key.WithKeys(menuItem.Key), key.WithHelp(menuItem.Key, menuItem.Label))
+		}
+		items = append(items, item{name: menuItem.Label, desc: menuItem.Description, command: menuItem.Command, key: binding})
+	}
+	return newModel(ctx, msg.Title, items, width, height)
+}
+
+func newModel(ctx *context.MainContext, title string, items []list.Item, width int, height int) *Model {
 	keyMap := config.Current.GetKeyMap()
 	menu := menu.NewMenu(items, width, height, keyMap, menu.WithStylePrefix("custom_commands"))
-	menu.Title = "Custom Commands"
+	menu.Title = title
 	menu.ShowShortcuts(true)
 	menu.FilterMatches = func(i list.Item, filter string) bool {
 		return strings.Contains(strings.ToLower(i.FilterValue()), strings.ToLower(filter))
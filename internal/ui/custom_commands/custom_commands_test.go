@@ -0,0 +1,269 @@
+package customcommands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/plugin"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/context"
+	"github.com/idursun/jjui/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadCommand(t *testing.T, content string, name string) context.CustomCommand {
+	registry, err := context.LoadCustomCommands(content)
+	require.NoError(t, err)
+	return registry[name]
+}
+
+const guardedCommand = `
+[custom_commands."create bookmark"]
+key = ["B"]
+args = ["bookmark", "create", "$input", "-r", "$change_id"]
+when = "mine()"
+prompt = "Bookmark name"
+confirm = true
+`
+
+func TestCustomCommand_WhenDoesNotMatch(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.MatchingRevision("abc", "mine()")).SetOutput([]byte(""))
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	ctx.SelectedItem = context.SelectedRevision{ChangeId: "abc", CommitId: "123"}
+
+	msg := loadCommand(t, guardedCommand, "create bookmark").Prepare(ctx)()
+	completed, ok := msg.(common.CommandCompletedMsg)
+	require.True(t, ok)
+	assert.ErrorContains(t, completed.Err, "not in `mine()`")
+}
+
+func TestCustomCommand_PromptAndConfirm(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.MatchingRevision("abc", "mine()")).SetOutput([]byte("abc"))
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	ctx.SelectedItem = context.SelectedRevision{ChangeId: "abc", CommitId: "123"}
+
+	msg := loadCommand(t, guardedCommand, "create bookmark").Prepare(ctx)()
+	prompt, ok := msg.(common.PromptMsg)
+	require.True(t, ok)
+	assert.Equal(t, "Bookmark name", prompt.Prompt)
+
+	confirm, ok := prompt.Accept("feature")().(common.ConfirmMsg)
+	require.True(t, ok)
+	assert.Equal(t, "create bookmark", confirm.Title)
+	assert.Equal(t, "jj bookmark create feature -r abc", confirm.Message)
+	assert.NotNil(t, confirm.Confirmed)
+}
+
+func TestCustomCommand_WithoutGuards(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	ctx.SelectedItem = context.SelectedRevision{ChangeId: "abc", CommitId: "123"}
+
+	command := loadCommand(t, `
+[custom_commands."show"]
+args = ["show", "-r", "$change_id"]
+show = "diff"
+`, "show")
+	commandRunner.Expect(jj.TemplatedArgs([]string{"show", "-r", "abc"}, nil)).SetOutput([]byte("diff output"))
+	assert.Equal(t, common.ShowDiffMsg("diff output"), command.Prepare(ctx)())
+}
+
+// collect runs the command and the commands of the batches and sequences it returns
+func collect(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	if v := reflect.ValueOf(msg); v.Kind() == reflect.Slice && v.Type().Elem() == reflect.TypeOf(cmd) {
+		var msgs []tea.Msg
+		for i := 0; i < v.Len(); i++ {
+			msgs = append(msgs, collect(v.Index(i).Interface().(tea.Cmd))...)
+		}
+		return msgs
+	}
+	return []tea.Msg{msg}
+}
+
+func TestCustomShellCommand(t *testing.T) {
+	t.Setenv("SHELL", "sh")
+	ctx := test.NewTestContext(test.NewTestCommandRunner(t))
+	ctx.Location = t.TempDir()
+	ctx.SelectedItem = context.SelectedRevision{ChangeId: "abc", CommitId: "123"}
+
+	tests := []struct {
+		show     string
+		expected tea.Msg
+	}{
+		{show: "flash", expected: common.CommandCompletedMsg{Output: "abc 123\n"}},
+		{show: "diff", expected: common.ShowDiffMsg("abc 123\n")},
+		{show: "preview", expected: common.ShowPreviewContentMsg("abc 123\n")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.show, func(t *testing.T) {
+			command := loadCommand(t, `
+[custom_commands."ids"]
+shell = "echo $change_id $commit_id"
+show = "`+tt.show+`"
+`, "ids")
+			assert.IsType(t, context.CustomShellCommand{}, command)
+			assert.Equal(t, "$ echo $change_id $commit_id", command.Description(ctx))
+			assert.Contains(t, collect(command.Prepare(ctx)), tt.expected)
+		})
+	}
+}
+
+func TestCustomShellCommand_IsApplicableToBracedPlaceholders(t *testing.T) {
+	command := loadCommand(t, `
+[custom_commands."open"]
+shell = "$EDITOR ${file}"
+`, "open")
+	assert.True(t, command.IsApplicableTo(context.SelectedFile{ChangeId: "abc", File: "a.txt"}))
+	assert.False(t, command.IsApplicableTo(context.SelectedRevision{ChangeId: "abc"}))
+}
+
+func TestCustomShellCommand_Failure(t *testing.T) {
+	t.Setenv("SHELL", "sh")
+	ctx := test.NewTestContext(test.NewTestCommandRunner(t))
+	ctx.Location = t.TempDir()
+
+	command := loadCommand(t, `
+[custom_commands."lint"]
+shell = "echo broken; exit 3"
+`, "lint")
+	msgs := collect(command.Prepare(ctx))
+	completed, ok := msgs[len(msgs)-1].(common.CommandCompletedMsg)
+	require.True(t, ok)
+	assert.Equal(t, "broken\n", completed.Output)
+	assert.EqualError(t, completed.Err, "lint exited with 3")
+}
+
+// writePlugin creates a plugin that saves its requests to requests.jsonl and replies with the given lines
+func writePlugin(t *testing.T, dir string, reply string) string {
+	script := filepath.Join(dir, "plugin.sh")
+	content := "#!/bin/sh\ncat >> requests.jsonl\ncat <<'EOF'\n" + reply + "\nEOF\n"
+	require.NoError(t, os.WriteFile(script, []byte(content), 0o755))
+	return script
+}
+
+func readRequests(t *testing.T, dir string) []plugin.Request {
+	data, err := os.ReadFile(filepath.Join(dir, "requests.jsonl"))
+	require.NoError(t, err)
+	var requests []plugin.Request
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	for decoder.More() {
+		var request plugin.Request
+		require.NoError(t, decoder.Decode(&request))
+		requests = append(requests, request)
+	}
+	return requests
+}
+
+func TestCustomPluginCommand(t *testing.T) {
+	dir := t.TempDir()
+	script := writePlugin(t, dir, `{"action": "flash", "message": "hello"}
+{"action": "set_revset", "revset": "mine()"}
+{"action": "show_diff", "text": "some text"}
+{"action": "menu", "title": "Pick", "items": [{"id": "one", "label": "First", "key": "1"}]}`)
+
+	ctx := test.NewTestContext(test.NewTestCommandRunner(t))
+	ctx.Location = dir
+	ctx.CurrentRevset = "all()"
+	ctx.SelectedItem = context.SelectedRevision{ChangeId: "abc", CommitId: "123"}
+	ctx.CheckedItems = []context.SelectedItem{context.SelectedFile{ChangeId: "abc", CommitId: "123", File: "a.txt"}}
+
+	command := loadCommand(t, `
+[custom_commands."review"]
+plugin = ["`+script+`", "--flag"]
+`, "review")
+	assert.IsType(t, context.CustomPluginCommand{}, command)
+	assert.Equal(t, "plugin "+script+" --flag", command.Description(ctx))
+
+	msgs := collect(command.Prepare(ctx))
+	assert.Contains(t, msgs, common.CommandCompletedMsg{Output: "hello"})
+	assert.Contains(t, msgs, common.UpdateRevSetMsg("mine()"))
+	assert.Contains(t, msgs, common.ShowDiffMsg("some text"))
+	menu, ok := msgs[len(msgs)-1].(common.ShowMenuMsg)
+	require.True(t, ok)
+	assert.Equal(t, "Pick", menu.Title)
+	require.Len(t, menu.Items, 1)
+	assert.Equal(t, "First", menu.Items[0].Label)
+	assert.Equal(t, "1", menu.Items[0].Key)
+
+	collect(menu.Items[0].Command)
+	requests := readRequests(t, dir)
+	require.Len(t, requests, 2)
+	assert.Equal(t, plugin.EventInvoke, requests[0].Event)
+	assert.Equal(t, plugin.ProtocolVersion, requests[0].Version)
+	assert.Equal(t, "all()", requests[0].Context.Revset)
+	assert.Equal(t, &plugin.Item{Type: plugin.ItemRevision, ChangeId: "abc", CommitId: "123"}, requests[0].Context.Selected)
+	assert.Equal(t, []plugin.Item{{Type: plugin.ItemFile, ChangeId: "abc", CommitId: "123", File: "a.txt"}}, requests[0].Context.Checked)
+	assert.Equal(t, "abc", requests[0].Context.Replacements[jj.ChangeIdPlaceholder])
+	assert.Equal(t, plugin.EventMenuSelect, requests[1].Event)
+	assert.Equal(t, "one", requests[1].MenuItem)
+}
+
+func TestCustomPluginCommand_RunAction(t *testing.T) {
+	dir := t.TempDir()
+	script := writePlugin(t, dir, `{"action": "run", "args": ["new", "-r", "abc"]}`)
+
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect([]string{"new", "-r", "abc"})
+	defer commandRunner.Verify()
+	ctx := test.NewTestContext(commandRunner)
+	ctx.Location = dir
+
+	command := loadCommand(t, `
+[custom_commands."new"]
+plugin = ["`+script+`"]
+`, "new")
+	collect(command.Prepare(ctx))
+}
+
+func TestCustomPluginCommand_Failure(t *testing.T) {
+	dir := t.TempDir()
+	ctx := test.NewTestContext(test.NewTestCommandRunner(t))
+	ctx.Location = dir
+
+	command := loadCommand(t, `
+[custom_commands."broken"]
+plugin = ["sh", "-c", "echo 'no repo' >&2; exit 2"]
+`, "broken")
+	msgs := collect(command.Prepare(ctx))
+	completed, ok := msgs[len(msgs)-1].(common.CommandCompletedMsg)
+	require.True(t, ok)
+	assert.EqualError(t, completed.Err, "broken: sh exited with 2: no repo")
+}
+
+func TestCustomPluginCommand_Timeout(t *testing.T) {
+	timeout := config.Current.UI.PluginTimeout
+	defer func() { config.Current.UI.PluginTimeout = timeout }()
+	config.Current.UI.PluginTimeout = 100
+
+	ctx := test.NewTestContext(test.NewTestCommandRunner(t))
+	ctx.Location = t.TempDir()
+
+	command := loadCommand(t, `
+[custom_commands."stuck"]
+plugin = ["sleep", "30"]
+`, "stuck")
+	msgs := collect(command.Prepare(ctx))
+	completed, ok := msgs[len(msgs)-1].(common.CommandCompletedMsg)
+	require.True(t, ok)
+	assert.EqualError(t, completed.Err, "stuck: timed out after 100ms")
+}
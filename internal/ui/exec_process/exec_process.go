@@ -85,14 +85,9 @@ func (p *process) Run() error {
 	cmd.Stdin = p.stdin
 	cmd.Stdout = p.stdout
 	cmd.Stderr = p.stderr
-	var env []string
-	for k, v := range p.env {
-		name := strings.TrimPrefix(k, "$")
-		env = append(env, name+"="+v)
-	}
 	// extend the current environment with context replacements.
 	// this is useful for sub-programs to access context vars.
-	cmd.Env = append(os.Environ(), env...)
+	cmd.Env = context.Environ(p.env)
 
 	// If program terminates quickly (most likely non-interactive commands),
 	// we ask the user to press a key, so they can at least see the output.
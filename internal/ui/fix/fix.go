@@ -0,0 +1,280 @@
+package fix
+
+import (
+	"bufio"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/common/menu"
+	"github.com/idursun/jjui/internal/ui/context"
+)
+
+type state int
+
+const (
+	statePreview state = iota
+	stateFixing
+	stateFixed
+)
+
+type updateToolsMsg struct {
+	items []list.Item
+}
+
+type fixCompletedMsg struct {
+	items []list.Item
+}
+
+type item struct {
+	name string
+	desc string
+	// set for the files rewritten by `jj fix`
+	from string
+	to   string
+	file string
+}
+
+func (i item) ShortCut() string {
+	return ""
+}
+
+func (i item) FilterValue() string {
+	return i.name
+}
+
+func (i item) Title() string {
+	return i.name
+}
+
+func (i item) Description() string {
+	return i.desc
+}
+
+type Model struct {
+	context   *context.MainContext
+	revisions jj.SelectedRevisions
+	keymap    config.KeyMappings[key.Binding]
+	menu      menu.Menu
+	state     state
+}
+
+func (m *Model) ShortHelp() []key.Binding {
+	if m.state == stateFixed {
+		return []key.Binding{
+			m.keymap.Cancel,
+			key.NewBinding(key.WithKeys(m.keymap.Apply.Keys()...), key.WithHelp(m.keymap.Apply.Help().Key, "show diff")),
+			m.menu.List.KeyMap.Filter,
+		}
+	}
+	return []key.Binding{
+		m.keymap.Cancel,
+		key.NewBinding(key.WithKeys(m.keymap.Apply.Keys()...), key.WithHelp(m.keymap.Apply.Help().Key, "run jj fix")),
+		m.menu.List.KeyMap.Filter,
+	}
+}
+
+func (m *Model) FullHelp() [][]key.Binding {
+	return [][]key.Binding{m.ShortHelp()}
+}
+
+func (m *Model) Width() int {
+	return m.menu.Width()
+}
+
+func (m *Model) Height() int {
+	return m.menu.Height()
+}
+
+func (m *Model) SetWidth(w int) {
+	m.menu.SetWidth(w)
+}
+
+func (m *Model) SetHeight(h int) {
+	m.menu.SetHeight(h)
+}
+
+func (m *Model) Init() tea.Cmd {
+	return m.loadTools
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.menu.List.SettingFilter() {
+			break
+		}
+		switch {
+		case key.Matches(msg, m.keymap.Cancel):
+			if m.menu.List.IsFiltered() {
+				m.menu.List.ResetFilter()
+				return m, nil
+			}
+			return m, common.Close
+		case key.Matches(msg, m.keymap.Apply):
+			switch m.state {
+			case statePreview:
+				m.state = stateFixing
+				m.menu.Title = "Fixing..."
+				return m, tea.Batch(common.CommandRunning(jj.Fix(m.revisions)), m.runFix)
+			case stateFixed:
+				if selected, ok := m.menu.List.SelectedItem().(item); ok && selected.file != "" {
+					return m, func() tea.Msg {
+						output, _ := m.context.RunCommandImmediate(jj.DiffFromTo(selected.from, selected.to, selected.file))
+						return common.ShowDiffMsg(output)
+					}
+				}
+			}
+			return m, nil
+		}
+	case updateToolsMsg:
+		m.menu.Items = msg.items
+		return m, m.menu.List.SetItems(m.menu.Items)
+	case fixCompletedMsg:
+		m.state = stateFixed
+		m.menu.Title = "Fix Results"
+		m.menu.Items = msg.items
+		m.menu.List.ResetSelected()
+		return m, m.menu.List.SetItems(m.menu.Items)
+	}
+	var cmd tea.Cmd
+	m.menu.List, cmd = m.menu.List.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) View() string {
+	return m.menu.View()
+}
+
+// loadTools lists the configured fix tools together with the changed files of the selected revisions they would run on
+func (m *Model) loadTools() tea.Msg {
+	output, err := m.context.RunCommandImmediate(jj.ConfigListFix())
+	if err != nil {
+		return common.CommandCompletedMsg{Output: string(output), Err: err}
+	}
+	jjConfig, err := config.DefaultConfig(output)
+	if err != nil {
+		return common.CommandCompletedMsg{Err: err}
+	}
+	var items []list.Item
+	for _, tool := range jjConfig.GetFixTools() {
+		if !tool.IsEnabled() {
+			items = append(items, item{name: tool.Name + " (disabled)", desc: strings.Join(tool.Command, " ")})
+			continue
+		}
+		var files []string
+		if len(tool.Patterns) > 0 {
+			out, _ := m.context.RunCommandImmediate(jj.FilesMatching(m.revisions, tool.Fileset()))
+			files = uniqueLines(string(out))
+		}
+		desc := fmt.Sprintf("%s · no matching files", tool.Fileset())
+		if len(files) > 0 {
+			desc = fmt.Sprintf("%s · %d file(s): %s", tool.Fileset(), len(files), strings.Join(files, ", "))
+		}
+		items = append(items, item{name: fmt.Sprintf("%s: %s", tool.Name, strings.Join(tool.Command, " ")), desc: desc})
+	}
+	if len(items) == 0 {
+		items = append(items, item{name: "no fix tools configured", desc: "see `fix.tools` in jj config"})
+	}
+	return updateToolsMsg{items: items}
+}
+
+// runFix runs `jj fix` and compares the commit ids before and after to find the rewritten revisions and files
+func (m *Model) runFix() tea.Msg {
+	if _, err := m.context.RunCommandImmediate(jj.Snapshot()); err != nil {
+		return common.CommandCompletedMsg{Err: err}
+	}
+	output, err := m.context.RunCommandImmediate(jj.GetChangeAndCommitIds(fmt.Sprintf("(%s)::", strings.Join(m.revisions.GetIds(), "|"))))
+	if err != nil {
+		return common.CommandCompletedMsg{Err: err}
+	}
+	before, changeIds := parseChangeAndCommitIds(string(output))
+
+	fixOutput, err := m.context.RunCommandImmediate(jj.Fix(m.revisions))
+	if err != nil {
+		return common.CommandCompletedMsg{Output: string(fixOutput), Err: err}
+	}
+
+	var items []list.Item
+	if len(changeIds) > 0 {
+		output, _ = m.context.RunCommandImmediate(jj.GetChangeAndCommitIds(strings.Join(changeIds, "|")))
+		after, _ := parseChangeAndCommitIds(string(output))
+		for _, changeId := range changeIds {
+			from, to := before[changeId], after[changeId]
+			if to == "" || from == to {
+				continue
+			}
+			summary, _ := m.context.RunCommandImmediate(jj.DiffSummary(from, to))
+			for _, line := range uniqueLines(string(summary)) {
+				status, file, found := strings.Cut(line, " ")
+				if !found {
+					continue
+				}
+				items = append(items, item{
+					name: fmt.Sprintf("%s %s", status, file),
+					desc: fmt.Sprintf("%s rewritten (%.8s → %.8s)", changeId, from, to),
+					from: from,
+					to:   to,
+					file: file,
+				})
+			}
+		}
+	}
+	if len(items) == 0 {
+		items = append(items, item{name: "nothing changed", desc: strings.TrimSpace(string(fixOutput))})
+	}
+	return tea.Batch(
+		func() tea.Msg { return fixCompletedMsg{items: items} },
+		func() tea.Msg { return common.CommandCompletedMsg{Output: string(fixOutput)} },
+		common.RefreshAndKeepSelections,
+	)()
+}
+
+func parseChangeAndCommitIds(output string) (map[string]string, []string) {
+	ids := make(map[string]string)
+	var changeIds []string
+	for _, line := range uniqueLines(output) {
+		changeId, commitId, found := strings.Cut(line, " ")
+		if !found {
+			continue
+		}
+		ids[changeId] = commitId
+		changeIds = append(changeIds, changeId)
+	}
+	return ids, changeIds
+}
+
+func uniqueLines(output string) []string {
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || slices.Contains(lines, line) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func NewModel(c *context.MainContext, revisions jj.SelectedRevisions, width int, height int) *Model {
+	keymap := config.Current.GetKeyMap()
+	menu := menu.NewMenu(nil, width, height, keymap, menu.WithStylePrefix("fix"))
+	menu.Title = fmt.Sprintf("Fix %s", strings.Join(revisions.GetIds(), " "))
+	m := &Model{
+		context:   c,
+		revisions: revisions,
+		keymap:    keymap,
+		menu:      menu,
+		state:     statePreview,
+	}
+	m.SetWidth(width)
+	m.SetHeight(height)
+	return m
+}
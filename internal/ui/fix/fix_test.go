@@ -0,0 +1,55 @@
+package fix
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/exp/teatest"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/test"
+)
+
+var revisions = jj.NewSelectedRevisions(&jj.Commit{ChangeId: "abc", CommitId: "123"})
+
+const fixConfig = `fix.tools.gofmt.command = ["gofmt"]
+fix.tools.gofmt.patterns = ["glob:'**/*.go'"]
+`
+
+func Test_PreviewListsToolsAndMatchingFiles(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.ConfigListFix()).SetOutput([]byte(fixConfig))
+	commandRunner.Expect(jj.FilesMatching(revisions, "glob:'**/*.go'")).SetOutput([]byte("main.go\nmain.go\n"))
+	defer commandRunner.Verify()
+
+	tm := teatest.NewTestModel(t, NewModel(test.NewTestContext(commandRunner), revisions, 80, 20))
+	teatest.WaitFor(t, tm.Output(), func(bts []byte) bool {
+		return bytes.Contains(bts, []byte("1 file(s): main.go"))
+	})
+	tm.Quit()
+	tm.WaitFinished(t, teatest.WithFinalTimeout(3*time.Second))
+}
+
+func Test_ApplyListsRewrittenFiles(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.ConfigListFix()).SetOutput([]byte(fixConfig))
+	commandRunner.Expect(jj.FilesMatching(revisions, "glob:'**/*.go'")).SetOutput([]byte("main.go\n"))
+	commandRunner.Expect(jj.Snapshot())
+	commandRunner.Expect(jj.GetChangeAndCommitIds("(abc)::")).SetOutput([]byte("abc 1111\nxyz 2222\n"))
+	commandRunner.Expect(jj.Fix(revisions)).SetOutput([]byte("Fixed 1 commits of 2 checked."))
+	commandRunner.Expect(jj.GetChangeAndCommitIds("abc|xyz")).SetOutput([]byte("abc 3333\nxyz 2222\n"))
+	commandRunner.Expect(jj.DiffSummary("1111", "3333")).SetOutput([]byte("M main.go\n"))
+	defer commandRunner.Verify()
+
+	tm := teatest.NewTestModel(t, NewModel(test.NewTestContext(commandRunner), revisions, 80, 20))
+	teatest.WaitFor(t, tm.Output(), func(bts []byte) bool {
+		return bytes.Contains(bts, []byte("gofmt"))
+	})
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	teatest.WaitFor(t, tm.Output(), func(bts []byte) bool {
+		return bytes.Contains(bts, []byte("M main.go"))
+	})
+	tm.Quit()
+	tm.WaitFinished(t, teatest.WithFinalTimeout(3*time.Second))
+}
@@ -39,6 +39,9 @@ func (m *Model) Init() tea.Cmd {
 
 func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case common.ConfigReloadedMsg:
+		m.successStyle, m.errorStyle = newStyles()
+		return m, nil
 	case expireMessageMsg:
 		for i, message := range m.messages {
 			if message.id == msg.id {
@@ -129,10 +132,15 @@ func (m *Model) nextId() uint64 {
 	return m.currentId
 }
 
-func New(context *context.MainContext) *Model {
+func newStyles() (lipgloss.Style, lipgloss.Style) {
 	fg := lipgloss.NewStyle().GetForeground()
 	successStyle := common.DefaultPalette.GetBorder("success", lipgloss.NormalBorder()).Foreground(fg).PaddingLeft(1).PaddingRight(1)
 	errorStyle := common.DefaultPalette.GetBorder("error", lipgloss.NormalBorder()).Foreground(fg).PaddingLeft(1).PaddingRight(1)
+	return successStyle, errorStyle
+}
+
+func New(context *context.MainContext) *Model {
+	successStyle, errorStyle := newStyles()
 	return &Model{
 		context:      context,
 		messages:     make([]flashMessage, 0),
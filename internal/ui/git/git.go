@@ -46,10 +46,14 @@ func (i item) Description() string {
 
 type Model struct {
 	context *context.MainContext
+	commit  *jj.Commit
 	keymap  config.KeyMappings[key.Binding]
 	menu    menu.Menu
 }
 
+// bookmarksLoadedMsg carries the bookmarks of the revision the menu was opened for
+type bookmarksLoadedMsg []jj.Bookmark
+
 func (m *Model) ShortHelp() []key.Binding {
 	return []key.Binding{
 		m.keymap.Cancel,
@@ -81,32 +85,42 @@ func (m *Model) SetHeight(h int) {
 }
 
 func (m *Model) Init() tea.Cmd {
-	return nil
+	if m.commit == nil {
+		return nil
+	}
+	return loadBookmarks(m.context, m.commit.GetChangeId())
 }
 
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
-	case tea.KeyMsg:
+	case bookmarksLoadedMsg:
+		m.menu.Items = append(bookmarkItems(msg), m.menu.Items...)
+		return m, m.menu.Filtered(m.menu.Filter)
+	case tea.KeyMsg, common.ActionMsg:
 		if m.menu.List.SettingFilter() {
 			break
 		}
 		switch {
-		case key.Matches(msg, m.keymap.Apply):
+		case common.Matches(msg, "revisions.apply", m.keymap.Apply):
 			action := m.menu.List.SelectedItem().(item)
 			return m, m.context.RunCommand(jj.Args(action.command...), common.Refresh, common.Close)
-		case key.Matches(msg, m.keymap.Cancel):
+		case common.Matches(msg, "revisions.cancel", m.keymap.Cancel):
 			if m.menu.Filter != "" || m.menu.List.IsFiltered() {
 				m.menu.List.ResetFilter()
 				return m.filtered("")
 			}
 			return m, common.Close
-		case key.Matches(msg, m.keymap.Git.Push) && m.menu.Filter != string(itemCategoryPush):
+		case common.Matches(msg, "git.push", m.keymap.Git.Push) && m.menu.Filter != string(itemCategoryPush):
 			return m.filtered(string(itemCategoryPush))
-		case key.Matches(msg, m.keymap.Git.Fetch) && m.menu.Filter != string(itemCategoryFetch):
+		case common.Matches(msg, "git.fetch", m.keymap.Git.Fetch) && m.menu.Filter != string(itemCategoryFetch):
 			return m.filtered(string(itemCategoryFetch))
 		default:
+			keyMsg, ok := msg.(tea.KeyMsg)
+			if !ok {
+				break
+			}
 			for _, listItem := range m.menu.List.Items() {
-				if item, ok := listItem.(item); ok && m.menu.Filter != "" && item.key == msg.String() {
+				if item, ok := listItem.(item); ok && m.menu.Filter != "" && item.key == keyMsg.String() {
 					return m, m.context.RunCommand(jj.Args(item.command...), common.Refresh, common.Close)
 				}
 			}
@@ -125,42 +139,43 @@ func (m *Model) View() string {
 	return m.menu.View()
 }
 
-func loadBookmarks(c context.CommandRunner, changeId string) []jj.Bookmark {
-	bytes, _ := c.RunCommandImmediate(jj.BookmarkList(changeId))
-	bookmarks := jj.ParseBookmarkListOutput(string(bytes))
-	return bookmarks
+func loadBookmarks(ctx *context.MainContext, changeId string) tea.Cmd {
+	return ctx.Query(jj.BookmarkList(changeId), func(output []byte, _ error) tea.Msg {
+		return bookmarksLoadedMsg(jj.ParseBookmarkListOutput(string(output)))
+	})
 }
 
-func NewModel(c *context.MainContext, commit *jj.Commit, width int, height int) *Model {
+func bookmarkItems(bookmarks []jj.Bookmark) []list.Item {
 	var items []list.Item
-	if commit != nil {
-		bookmarks := loadBookmarks(c, commit.GetChangeId())
-		for _, b := range bookmarks {
-			if b.Conflict {
-				continue
-			}
-			for _, remote := range b.Remotes {
-				items = append(items, item{
-					name:     fmt.Sprintf("git push --bookmark %s --remote %s", b.Name, remote.Remote),
-					desc:     fmt.Sprintf("Git push bookmark %s to remote %s", b.Name, remote.Remote),
-					command:  jj.GitPush("--bookmark", b.Name, "--remote", remote.Remote),
-					category: itemCategoryPush,
-				})
-			}
-			if b.IsPushable() {
-				items = append(items, item{
-					name:     fmt.Sprintf("git push --bookmark %s --allow-new", b.Name),
-					desc:     fmt.Sprintf("Git push new bookmark %s", b.Name),
-					command:  jj.GitPush("--bookmark", b.Name, "--allow-new"),
-					category: itemCategoryPush,
-				})
-			}
+	for _, b := range bookmarks {
+		if b.Conflict {
+			continue
+		}
+		for _, remote := range b.Remotes {
+			items = append(items, item{
+				name:     fmt.Sprintf("git push --bookmark %s --remote %s", b.Name, remote.Remote),
+				desc:     fmt.Sprintf("Git push bookmark %s to remote %s", b.Name, remote.Remote),
+				command:  jj.GitPush("--bookmark", b.Name, "--remote", remote.Remote),
+				category: itemCategoryPush,
+			})
+		}
+		if b.IsPushable() {
+			items = append(items, item{
+				name:     fmt.Sprintf("git push --bookmark %s --allow-new", b.Name),
+				desc:     fmt.Sprintf("Git push new bookmark %s", b.Name),
+				command:  jj.GitPush("--bookmark", b.Name, "--allow-new"),
+				category: itemCategoryPush,
+			})
 		}
 	}
-	items = append(items,
+	return items
+}
+
+func NewModel(c *context.MainContext, commit *jj.Commit, width int, height int) *Model {
+	items := []list.Item{
 		item{name: "git push", desc: "Push tracking bookmarks in the current revset", command: jj.GitPush(), category: itemCategoryPush, key: "p"},
 		item{name: "git push --all", desc: "Push all bookmarks (including new and deleted bookmarks)", command: jj.GitPush("--all"), category: itemCategoryPush, key: "a"},
-	)
+	}
 	if commit != nil {
 		items = append(items,
 			item{
@@ -192,6 +207,7 @@ func NewModel(c *context.MainContext, commit *jj.Commit, width int, height int)
 
 	m := &Model{
 		context: c,
+		commit:  commit,
 		menu:    menu,
 		keymap:  keymap,
 	}
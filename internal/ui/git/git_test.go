@@ -56,8 +56,8 @@ test;.;false;false;false;d0
 `))
 	defer commandRunner.Verify()
 
-	bookmarks := loadBookmarks(commandRunner, changeId)
-	assert.Len(t, bookmarks, 3)
+	msg := loadBookmarks(test.NewTestContext(commandRunner), changeId)()
+	assert.Len(t, msg, 3)
 }
 
 func Test_PushChange(t *testing.T) {
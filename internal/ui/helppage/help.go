@@ -108,7 +108,6 @@ func (h *Model) View() string {
 		h.printKeyBinding(h.keyMap.QuickSearch),
 		h.printKeyBinding(h.keyMap.QuickSearchCycle),
 		h.printKeyBinding(h.keyMap.FileSearch.Toggle),
-		h.printKeyBinding(h.keyMap.New),
 		h.printKeyBinding(h.keyMap.Commit),
 		h.printKeyBinding(h.keyMap.Describe),
 		h.printKeyBinding(h.keyMap.Edit),
@@ -116,7 +115,10 @@ func (h *Model) View() string {
 		h.printKeyBinding(h.keyMap.Diffedit),
 		h.printKeyBinding(h.keyMap.Split),
 		h.printKeyBinding(h.keyMap.Abandon),
+		h.printKeyBinding(h.keyMap.Sign),
+		h.printKeyBinding(h.keyMap.Metadata.Mode),
 		h.printKeyBinding(h.keyMap.Absorb),
+		h.printKeyBinding(h.keyMap.Fix),
 		h.printKeyBinding(h.keyMap.Undo),
 		h.printKeyBinding(h.keyMap.Details.Mode),
 		h.printKeyBinding(h.keyMap.Bookmark.Set),
@@ -159,6 +161,13 @@ func (h *Model) View() string {
 		h.printKeyBinding(h.keyMap.Duplicate.Onto),
 		h.printKeyBinding(h.keyMap.Duplicate.Before),
 		h.printKeyBinding(h.keyMap.Duplicate.After),
+		"",
+		h.printMode(h.keyMap.New, "New"),
+		h.printKeyBinding(h.keyMap.NewPlacement.Onto),
+		h.printKeyBinding(h.keyMap.NewPlacement.Before),
+		h.printKeyBinding(h.keyMap.NewPlacement.After),
+		h.printKeyBinding(h.keyMap.NewPlacement.Insert),
+		h.printKeyBinding(h.keyMap.NewPlacement.ToggleParent),
 	)
 
 	var right []string
@@ -170,6 +179,13 @@ func (h *Model) View() string {
 		h.printKeyBinding(h.keyMap.Preview.HalfPageUp),
 		h.printKeyBinding(h.keyMap.Preview.Expand),
 		h.printKeyBinding(h.keyMap.Preview.Shrink),
+		h.printKeyBinding(h.keyMap.Preview.Search),
+		h.printKeyBinding(h.keyMap.Preview.SearchNext),
+		h.printKeyBinding(h.keyMap.Preview.SearchPrev),
+		h.printKeyBinding(h.keyMap.Preview.ToggleWrap),
+		h.printKeyBinding(h.keyMap.Preview.ScrollLeft),
+		h.printKeyBinding(h.keyMap.Preview.ScrollRight),
+		h.printKeyBinding(h.keyMap.Preview.CycleView),
 		h.printKeyBinding(h.keyMap.Preview.ToggleBottom),
 		"",
 		h.printMode(h.keyMap.Git.Mode, "Git"),
@@ -185,6 +201,11 @@ func (h *Model) View() string {
 		h.printMode(h.keyMap.OpLog.Mode, "Oplog"),
 		h.printKeyBinding(h.keyMap.Diff),
 		h.printKeyBinding(h.keyMap.OpLog.Restore),
+		h.printMode(h.keyMap.Palette.Mode, "Palette"),
+		h.printKeyBinding(h.keyMap.Palette.NextTheme),
+		h.printKeyBinding(h.keyMap.Palette.PrevTheme),
+		h.printMode(h.keyMap.CommandStats.Mode, "Command Stats"),
+		h.printKeyBinding(h.keyMap.CommandStats.Export),
 		h.printMode(h.keyMap.Leader, "Leader"),
 		h.printMode(h.keyMap.CustomCommands, "Custom Commands"),
 	)
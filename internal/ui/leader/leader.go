@@ -3,25 +3,52 @@ package leader
 import (
 	"maps"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/idursun/jjui/internal/config"
 	"github.com/idursun/jjui/internal/ui/common"
 	"github.com/idursun/jjui/internal/ui/context"
 )
 
 type Model struct {
+	*common.Sizeable
 	cancel  key.Binding
+	level   context.LeaderMap
 	shown   context.LeaderMap
+	prefix  string
+	visible bool
+	entered int
+	styles  styles
 	context *context.MainContext
 }
 
+type styles struct {
+	border   lipgloss.Style
+	title    lipgloss.Style
+	shortcut lipgloss.Style
+	text     lipgloss.Style
+	group    lipgloss.Style
+	dimmed   lipgloss.Style
+}
+
 func New(ctx *context.MainContext) *Model {
 	keyMap := config.Current.GetKeyMap()
 	m := &Model{
-		context: ctx,
-		cancel:  keyMap.Cancel,
+		Sizeable: common.NewSizeable(0, 0),
+		context:  ctx,
+		cancel:   keyMap.Cancel,
+		styles: styles{
+			border:   common.DefaultPalette.GetBorder("leader border", lipgloss.RoundedBorder()).Padding(0, 1),
+			title:    common.DefaultPalette.Get("leader title"),
+			shortcut: common.DefaultPalette.Get("leader shortcut"),
+			text:     common.DefaultPalette.Get("leader text"),
+			group:    common.DefaultPalette.Get("leader group"),
+			dimmed:   common.DefaultPalette.Get("leader dimmed"),
+		},
 	}
 	return m
 }
@@ -45,23 +72,34 @@ func InitCmd() tea.Msg {
 	return initMsg{}
 }
 
+// showMsg makes the which-key popup visible once the delay after entering a level has passed
+type showMsg struct {
+	entered int
+}
+
 func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case initMsg:
-		m.shown = contextEnabled(m.context, m.context.Leader)
+		return m, m.enter(m.context.Leader, "")
+	case showMsg:
+		if msg.entered == m.entered {
+			m.visible = true
+		}
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, m.cancel):
 			m.shown = nil
 			return m, common.Close
 		}
-		for c := range maps.Values(m.shown) {
+		for k, c := range m.shown {
 			if key.Matches(msg, *c.Bind) {
 				if len(c.Nest) > 0 {
-					m.shown = contextEnabled(m.context, c.Nest)
-					return m, nil
+					return m, m.enter(c.Nest, m.prefix+k)
 				}
 				m.shown = nil
+				if len(c.Action) > 0 {
+					return m, tea.Sequence(common.Close, common.InvokeAction(c.Action[0], c.Action[1:]...))
+				}
 				cmds := sendCmds(c.Send)
 				return m, tea.Batch(
 					common.Close,
@@ -73,6 +111,79 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 	return m, nil
 }
 
+// enter moves to a level of the leader map. The popup for the level is shown after `ui.which_key.delay`
+// so that it stays hidden while a known sequence is typed quickly.
+func (m *Model) enter(level context.LeaderMap, prefix string) tea.Cmd {
+	m.level = level
+	m.shown = contextEnabled(m.context, level)
+	m.prefix = prefix
+	m.visible = false
+	m.entered++
+
+	whichKey := config.Current.UI.WhichKey
+	if !whichKey.Enabled {
+		return nil
+	}
+	if whichKey.Delay <= 0 {
+		m.visible = true
+		return nil
+	}
+	entered := m.entered
+	return tea.Tick(time.Duration(whichKey.Delay)*time.Millisecond, func(time.Time) tea.Msg {
+		return showMsg{entered: entered}
+	})
+}
+
+// View renders the which-key popup listing every key of the current level. Keys leading to nested
+// levels are shown as groups and the keys that are not available for the selected item are dimmed.
+func (m *Model) View() string {
+	if !m.visible || len(m.level) == 0 {
+		return ""
+	}
+	keys := slices.Sorted(maps.Keys(m.level))
+	entries := make([]string, 0, len(keys))
+	for _, k := range keys {
+		entry := m.level[k]
+		desc := entry.Bind.Help().Desc
+		descStyle := m.styles.text
+		if len(entry.Nest) > 0 {
+			if desc == "" {
+				desc = strings.Join(slices.Sorted(maps.Keys(entry.Nest)), " ")
+			}
+			desc = "+" + desc
+			descStyle = m.styles.group
+		}
+		shortcutStyle := m.styles.shortcut
+		if _, ok := m.shown[k]; !ok {
+			shortcutStyle = m.styles.dimmed
+			descStyle = m.styles.dimmed
+		}
+		entries = append(entries, shortcutStyle.Render(k)+descStyle.Render(" "+desc))
+	}
+
+	// border, padding and title take 4 rows and columns
+	rows := len(entries)
+	if m.Height > 4 {
+		rows = min(rows, m.Height-4)
+	}
+	var columns []string
+	for column := range slices.Chunk(entries, max(rows, 1)) {
+		column = slices.Clone(column)
+		if len(columns) > 0 {
+			for i := range column {
+				column[i] = m.styles.text.Render("   ") + column[i]
+			}
+		}
+		columns = append(columns, lipgloss.JoinVertical(lipgloss.Left, column...))
+	}
+	content := lipgloss.JoinHorizontal(lipgloss.Top, columns...)
+	if m.Width > 4 {
+		content = lipgloss.NewStyle().MaxWidth(m.Width - 4).Render(content)
+	}
+	title := m.styles.title.Render(strings.TrimSpace("leader " + m.prefix))
+	return m.styles.border.Render(lipgloss.JoinVertical(lipgloss.Left, title, content))
+}
+
 func contextEnabled(ctx *context.MainContext, bnds context.LeaderMap) context.LeaderMap {
 	bnds = maps.Clone(bnds)
 	replacementKeys := slices.Collect(maps.Keys(ctx.CreateReplacements()))
@@ -92,132 +203,12 @@ func contextEnabled(ctx *context.MainContext, bnds context.LeaderMap) context.Le
 
 func sendCmds(strings []string) []tea.Cmd {
 	var cmds []tea.Cmd
-	send := func(k tea.Key) {
-		cmds = append(cmds, func() tea.Msg {
-			return tea.KeyMsg(k)
-		})
-	}
 	for _, s := range strings {
-		if k, ok := keyNames[s]; ok {
-			send(k)
-		} else {
-			for _, r := range s {
-				send(tea.Key{
-					Type:  tea.KeyRunes,
-					Runes: []rune{r},
-				})
-			}
+		for _, k := range context.KeyMsgs(s) {
+			cmds = append(cmds, func() tea.Msg {
+				return k
+			})
 		}
 	}
 	return cmds
 }
-
-// From bubbletea's key.go. So that we can identify by their string.
-// Notable Exception: tea.KeyRunes. Because we create them.
-var keyTypes = []tea.KeyType{
-	// Control keys.
-	tea.KeyTab,
-	tea.KeyEnter,
-	tea.KeyEsc,
-	tea.KeyBackspace,
-
-	tea.KeyCtrlAt,
-	tea.KeyCtrlA,
-	tea.KeyCtrlB,
-	tea.KeyCtrlC,
-	tea.KeyCtrlD,
-	tea.KeyCtrlE,
-	tea.KeyCtrlF,
-	tea.KeyCtrlG,
-	tea.KeyCtrlH,
-	tea.KeyCtrlJ,
-	tea.KeyCtrlK,
-	tea.KeyCtrlL,
-	tea.KeyCtrlN,
-	tea.KeyCtrlO,
-	tea.KeyCtrlP,
-	tea.KeyCtrlQ,
-	tea.KeyCtrlR,
-	tea.KeyCtrlS,
-	tea.KeyCtrlT,
-	tea.KeyCtrlU,
-	tea.KeyCtrlV,
-	tea.KeyCtrlW,
-	tea.KeyCtrlX,
-	tea.KeyCtrlY,
-	tea.KeyCtrlZ,
-
-	tea.KeyCtrlCloseBracket,
-	tea.KeyCtrlCaret,
-	tea.KeyCtrlUnderscore,
-	tea.KeyCtrlBackslash,
-
-	// Other keys.
-	tea.KeyUp,
-	tea.KeyDown,
-	tea.KeyRight,
-	tea.KeySpace,
-	tea.KeyLeft,
-	tea.KeyShiftTab,
-	tea.KeyHome,
-	tea.KeyEnd,
-	tea.KeyCtrlHome,
-	tea.KeyCtrlEnd,
-	tea.KeyShiftHome,
-	tea.KeyShiftEnd,
-	tea.KeyCtrlShiftHome,
-	tea.KeyCtrlShiftEnd,
-	tea.KeyPgUp,
-	tea.KeyPgDown,
-	tea.KeyCtrlPgUp,
-	tea.KeyCtrlPgDown,
-	tea.KeyDelete,
-	tea.KeyInsert,
-	tea.KeyCtrlUp,
-	tea.KeyCtrlDown,
-	tea.KeyCtrlRight,
-	tea.KeyCtrlLeft,
-	tea.KeyShiftUp,
-	tea.KeyShiftDown,
-	tea.KeyShiftRight,
-	tea.KeyShiftLeft,
-	tea.KeyCtrlShiftUp,
-	tea.KeyCtrlShiftDown,
-	tea.KeyCtrlShiftLeft,
-	tea.KeyCtrlShiftRight,
-	tea.KeyF1,
-	tea.KeyF2,
-	tea.KeyF3,
-	tea.KeyF4,
-	tea.KeyF5,
-	tea.KeyF6,
-	tea.KeyF7,
-	tea.KeyF8,
-	tea.KeyF9,
-	tea.KeyF10,
-	tea.KeyF11,
-	tea.KeyF12,
-	tea.KeyF13,
-	tea.KeyF14,
-	tea.KeyF15,
-	tea.KeyF16,
-	tea.KeyF17,
-	tea.KeyF18,
-	tea.KeyF19,
-	tea.KeyF20,
-}
-
-func keysFromTypes() map[string]tea.Key {
-	m := map[string]tea.Key{}
-	set := func(t tea.KeyType) {
-		m[t.String()] = tea.Key{
-			Type: t,
-		}
-	}
-	for _, t := range keyTypes {
-		set(t)
-	}
-	return m
-}
-
-var keyNames = keysFromTypes()
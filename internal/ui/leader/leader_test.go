@@ -1,9 +1,12 @@
 package leader
 
 import (
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/idursun/jjui/internal/config"
 	"github.com/idursun/jjui/internal/ui/common"
 	"github.com/idursun/jjui/internal/ui/context"
 )
@@ -177,3 +180,71 @@ send = ["?"]
 		t.Errorf("expected CloseViewMsg, got %T", msgOut)
 	}
 }
+
+func TestView_lists_keys_of_current_level(t *testing.T) {
+	content := `[leader.h]
+help = "Help"
+send = ["?"]
+
+[leader.n]
+help = "New on selected"
+context = [ "$change_id" ]
+send = ["n"]
+
+[leader.g]
+help = "Git"
+
+[leader.gp]
+help = "Git Push"
+send = ["gp"]
+`
+	lm, err := context.LoadLeader(content)
+	if err != nil {
+		t.Fatalf("LoadLeader failed: %v", err)
+	}
+	model := New(&context.MainContext{Leader: lm})
+	model.SetWidth(80)
+	model.SetHeight(20)
+	model, _ = model.Update(initMsg{})
+
+	view := ansi.Strip(model.View())
+	for _, expected := range []string{"leader", "h Help", "n New on selected", "g +Git"} {
+		if !strings.Contains(view, expected) {
+			t.Errorf("expected %q in view:\n%s", expected, view)
+		}
+	}
+	if _, ok := model.shown["n"]; ok {
+		t.Error("expected n to be unavailable without a selected revision")
+	}
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	view = ansi.Strip(model.View())
+	if !strings.Contains(view, "leader g") || !strings.Contains(view, "p Git Push") {
+		t.Errorf("expected nested level in view:\n%s", view)
+	}
+}
+
+func TestView_waits_for_delay(t *testing.T) {
+	config.Current.UI.WhichKey.Delay = 100
+	defer func() { config.Current.UI.WhichKey.Delay = 0 }()
+
+	lm, err := context.LoadLeader(`[leader.h]
+send = ["?"]
+`)
+	if err != nil {
+		t.Fatalf("LoadLeader failed: %v", err)
+	}
+	model := New(&context.MainContext{Leader: lm})
+	model, cmd := model.Update(initMsg{})
+	if cmd == nil || model.View() != "" {
+		t.Fatal("expected the popup to wait for the delay")
+	}
+	model, _ = model.Update(showMsg{entered: model.entered - 1})
+	if model.View() != "" {
+		t.Fatal("expected a stale show message to be ignored")
+	}
+	model, _ = model.Update(showMsg{entered: model.entered})
+	if model.View() == "" {
+		t.Fatal("expected the popup after the delay")
+	}
+}
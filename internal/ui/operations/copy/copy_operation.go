@@ -48,7 +48,8 @@ func (c *Operation) Init() tea.Cmd {
 }
 
 func (c *Operation) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if msg, ok := msg.(tea.KeyMsg); ok {
+	switch msg.(type) {
+	case tea.KeyMsg, common.ActionMsg:
 		return c, c.HandleKey(msg)
 	}
 	return c, nil
@@ -58,21 +59,21 @@ func (c *Operation) View() string {
 	return ""
 }
 
-func (c *Operation) HandleKey(msg tea.KeyMsg) tea.Cmd {
+func (c *Operation) HandleKey(msg tea.Msg) tea.Cmd {
 	switch {
-	case key.Matches(msg, c.keyMap.Copy.ChangeId):
+	case common.Matches(msg, "copy.change_id", c.keyMap.Copy.ChangeId):
 		c.target = CopyChangeId
 		return c.copyToClipboard()
-	case key.Matches(msg, c.keyMap.Copy.CommitId):
+	case common.Matches(msg, "copy.commit_id", c.keyMap.Copy.CommitId):
 		c.target = CopyCommitId
 		return c.copyToClipboard()
-	case key.Matches(msg, c.keyMap.Copy.Description):
+	case common.Matches(msg, "copy.description", c.keyMap.Copy.Description):
 		c.target = CopyDescription
 		return c.copyToClipboard()
-	case key.Matches(msg, c.keyMap.Copy.FullInfo):
+	case common.Matches(msg, "copy.full_info", c.keyMap.Copy.FullInfo):
 		c.target = CopyFullInfo
 		return c.copyToClipboard()
-	case key.Matches(msg, c.keyMap.Cancel):
+	case common.Matches(msg, "revisions.cancel", c.keyMap.Cancel):
 		return common.Close
 	}
 	return nil
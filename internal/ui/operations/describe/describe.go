@@ -1,6 +1,8 @@
 package describe
 
 import (
+	"strings"
+
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
@@ -20,6 +22,10 @@ type Operation struct {
 	keyMap   config.KeyMappings[key.Binding]
 	input    textarea.Model
 	revision string
+	// commit runs `jj commit` with the checked files instead of `jj describe` when set
+	commit      bool
+	files       []string
+	dimmedStyle lipgloss.Style
 }
 
 func (o Operation) IsEditing() bool {
@@ -70,11 +76,20 @@ func (o Operation) Name() string {
 }
 
 func (o Operation) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+	switch msg.(type) {
+	case tea.KeyMsg, common.ActionMsg:
 		switch {
-		case key.Matches(keyMsg, o.keyMap.Cancel):
+		case common.Matches(msg, "revisions.cancel", o.keyMap.Cancel):
 			return o, common.Close
-		case key.Matches(keyMsg, o.keyMap.InlineDescribe.Editor):
+		case o.commit && common.Matches(msg, "inline_describe.editor", o.keyMap.InlineDescribe.Editor):
+			return o, o.context.RunCommand(
+				jj.SetDescription(o.revision, o.input.Value()),
+				common.Close,
+				o.context.RunInteractiveCommand(jj.CommitWorkingCopy(o.files), common.RefreshAndSelect("@")),
+			)
+		case o.commit && common.Matches(msg, "inline_describe.accept", o.keyMap.InlineDescribe.Accept):
+			return o, o.context.RunCommand(jj.CommitWithMessage(o.input.Value(), o.files), common.Close, common.RefreshAndSelect("@"))
+		case common.Matches(msg, "inline_describe.editor", o.keyMap.InlineDescribe.Editor):
 			commit := &jj.Commit{
 				ChangeId: o.revision,
 			}
@@ -84,7 +99,7 @@ func (o Operation) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				common.Close,
 				o.context.RunInteractiveCommand(jj.Describe(selectedRevisions), common.Refresh),
 			)
-		case key.Matches(keyMsg, o.keyMap.InlineDescribe.Accept):
+		case common.Matches(msg, "inline_describe.accept", o.keyMap.InlineDescribe.Accept):
 			return o, o.context.RunCommand(jj.SetDescription(o.revision, o.input.Value()), common.Close, common.Refresh)
 		}
 	}
@@ -105,9 +120,20 @@ func (o Operation) Init() tea.Cmd {
 }
 
 func (o Operation) View() string {
+	if o.commit && len(o.files) > 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, o.dimmedStyle.Render("committing "+strings.Join(o.files, ", ")), o.input.View())
+	}
 	return o.input.View()
 }
 
+// NewCommitOperation reuses the inline describe editor for `jj commit`, committing only the given files when there are any
+func NewCommitOperation(context *context.MainContext, revision string, files []string, width int) Operation {
+	op := NewOperation(context, revision, width)
+	op.commit = true
+	op.files = files
+	return op
+}
+
 func NewOperation(context *context.MainContext, revision string, width int) Operation {
 	descOutput, _ := context.RunCommandImmediate(jj.GetDescription(revision))
 	desc := string(descOutput)
@@ -128,9 +154,10 @@ func NewOperation(context *context.MainContext, revision string, width int) Oper
 	input.Focus()
 
 	return Operation{
-		context:  context,
-		keyMap:   config.Current.GetKeyMap(),
-		input:    input,
-		revision: revision,
+		context:     context,
+		keyMap:      config.Current.GetKeyMap(),
+		input:       input,
+		revision:    revision,
+		dimmedStyle: common.DefaultPalette.Get("revisions dimmed"),
 	}
 }
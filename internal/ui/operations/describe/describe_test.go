@@ -0,0 +1,54 @@
+package describe
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/exp/teatest"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CommitAcceptCommitsCheckedFiles(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetDescription("a")).SetOutput([]byte("fix the bug"))
+	commandRunner.Expect(jj.CommitWithMessage("fix the bug", []string{"main.go"}))
+	defer commandRunner.Verify()
+
+	op := NewCommitOperation(test.NewTestContext(commandRunner), "a", []string{"main.go"}, 80)
+	tm := teatest.NewTestModel(t, op)
+	tm.Send(tea.KeyMsg{Type: tea.KeyCtrlS})
+	teatest.WaitFor(t, tm.Output(), func(bts []byte) bool {
+		return commandRunner.IsVerified()
+	})
+	tm.Quit()
+	tm.WaitFinished(t, teatest.WithFinalTimeout(3*time.Second))
+}
+
+func Test_CommitEditorSavesMessageAndOpensEditor(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetDescription("a")).SetOutput([]byte("wip"))
+	commandRunner.Expect(jj.SetDescription("a", "wip"))
+	commandRunner.Expect(jj.CommitWorkingCopy(nil))
+	defer commandRunner.Verify()
+
+	op := NewCommitOperation(test.NewTestContext(commandRunner), "a", nil, 80)
+	tm := teatest.NewTestModel(t, op)
+	tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e"), Alt: true})
+	teatest.WaitFor(t, tm.Output(), func(bts []byte) bool {
+		return commandRunner.IsVerified()
+	})
+	tm.Quit()
+	tm.WaitFinished(t, teatest.WithFinalTimeout(3*time.Second))
+}
+
+func Test_CommitListsCheckedFiles(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetDescription("a")).SetOutput([]byte("wip"))
+	defer commandRunner.Verify()
+
+	op := NewCommitOperation(test.NewTestContext(commandRunner), "a", []string{"main.go", "go.mod"}, 80)
+	assert.Contains(t, op.View(), "committing main.go, go.mod")
+}
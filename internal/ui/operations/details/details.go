@@ -98,22 +98,22 @@ func (s *Operation) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (s *Operation) internalUpdate(msg tea.Msg) (*Operation, tea.Cmd) {
 	switch msg := msg.(type) {
-	case tea.KeyMsg:
+	case tea.KeyMsg, common.ActionMsg:
 		if s.confirmation != nil {
 			model, cmd := s.confirmation.Update(msg)
 			s.confirmation = model
 			return s, cmd
 		}
 		switch {
-		case key.Matches(msg, s.keyMap.Up):
+		case common.Matches(msg, "revisions.up", s.keyMap.Up):
 			s.cursorUp()
 			return s, nil
-		case key.Matches(msg, s.keyMap.Down):
+		case common.Matches(msg, "revisions.down", s.keyMap.Down):
 			s.cursorDown()
 			return s, nil
-		case key.Matches(msg, s.keyMap.Cancel), key.Matches(msg, s.keyMap.Details.Close):
+		case common.Matches(msg, "revisions.cancel", s.keyMap.Cancel), common.Matches(msg, "details.close", s.keyMap.Details.Close):
 			return s, common.Close
-		case key.Matches(msg, s.keyMap.Details.Diff):
+		case common.Matches(msg, "details.diff", s.keyMap.Details.Diff):
 			selected := s.current()
 			if selected == nil {
 				return s, nil
@@ -122,7 +122,7 @@ func (s *Operation) internalUpdate(msg tea.Msg) (*Operation, tea.Cmd) {
 				output, _ := s.context.RunCommandImmediate(jj.Diff(s.revision.GetChangeId(), selected.fileName))
 				return common.ShowDiffMsg(output)
 			}
-		case key.Matches(msg, s.keyMap.Details.Split):
+		case common.Matches(msg, "details.split", s.keyMap.Details.Split):
 			selectedFiles := s.getSelectedFiles()
 			s.selectedHint = "stays as is"
 			s.unselectedHint = "moves to the new revision"
@@ -138,11 +138,15 @@ func (s *Operation) internalUpdate(msg tea.Msg) (*Operation, tea.Cmd) {
 			)
 			s.confirmation = model
 			return s, s.confirmation.Init()
-		case key.Matches(msg, s.keyMap.Details.Squash):
+		case common.Matches(msg, "details.squash", s.keyMap.Details.Squash):
 			return s, func() tea.Msg {
 				return common.StartSquashOperationMsg{Revision: s.revision, Files: s.getSelectedFiles()}
 			}
-		case key.Matches(msg, s.keyMap.Details.Restore):
+		case s.revision.IsWorkingCopy && common.Matches(msg, "revisions.commit", s.keyMap.Commit):
+			return s, func() tea.Msg {
+				return common.StartCommitOperationMsg{Files: s.getSelectedFiles()}
+			}
+		case common.Matches(msg, "details.restore", s.keyMap.Details.Restore):
 			selectedFiles := s.getSelectedFiles()
 			s.selectedHint = "gets restored"
 			s.unselectedHint = "stays as is"
@@ -158,7 +162,7 @@ func (s *Operation) internalUpdate(msg tea.Msg) (*Operation, tea.Cmd) {
 			)
 			s.confirmation = model
 			return s, s.confirmation.Init()
-		case key.Matches(msg, s.keyMap.Details.Absorb):
+		case common.Matches(msg, "details.absorb", s.keyMap.Details.Absorb):
 			selectedFiles := s.getSelectedFiles()
 			s.selectedHint = "might get absorbed into parents"
 			s.unselectedHint = "stays as is"
@@ -174,7 +178,7 @@ func (s *Operation) internalUpdate(msg tea.Msg) (*Operation, tea.Cmd) {
 			)
 			s.confirmation = model
 			return s, s.confirmation.Init()
-		case key.Matches(msg, s.keyMap.Details.ToggleSelect):
+		case common.Matches(msg, "details.select", s.keyMap.Details.ToggleSelect):
 			if current := s.current(); current != nil {
 				isChecked := !current.selected
 				current.selected = isChecked
@@ -193,7 +197,7 @@ func (s *Operation) internalUpdate(msg tea.Msg) (*Operation, tea.Cmd) {
 				s.cursorDown()
 			}
 			return s, nil
-		case key.Matches(msg, s.keyMap.Details.RevisionsChangingFile):
+		case common.Matches(msg, "details.revisions_changing_file", s.keyMap.Details.RevisionsChangingFile):
 			if current := s.current(); current != nil {
 				return s, tea.Batch(common.Close, common.UpdateRevSet(fmt.Sprintf("files(%s)", jj.EscapeFileName(current.fileName))))
 			}
@@ -238,7 +242,7 @@ func (s *Operation) ShortHelp() []key.Binding {
 	if s.confirmation != nil {
 		return s.confirmation.ShortHelp()
 	}
-	return []key.Binding{
+	bindings := []key.Binding{
 		s.keyMap.Cancel,
 		s.keyMap.Details.Diff,
 		s.keyMap.Details.ToggleSelect,
@@ -248,6 +252,10 @@ func (s *Operation) ShortHelp() []key.Binding {
 		s.keyMap.Details.Absorb,
 		s.keyMap.Details.RevisionsChangingFile,
 	}
+	if s.revision.IsWorkingCopy {
+		bindings = append(bindings, s.keyMap.Commit)
+	}
+	return bindings
 }
 
 func (s *Operation) FullHelp() [][]key.Binding {
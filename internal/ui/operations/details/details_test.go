@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/idursun/jjui/test"
 
@@ -102,3 +104,19 @@ func TestModel_Update_HandlesMovedFiles(t *testing.T) {
 	tm.Quit()
 	tm.WaitFinished(t, teatest.WithFinalTimeout(3*time.Second))
 }
+
+func TestModel_Update_CommitsSelectedFilesOfWorkingCopy(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.Snapshot())
+	commandRunner.Expect(jj.Status(Revision)).SetOutput([]byte(StatusOutput))
+	defer commandRunner.Verify()
+
+	workingCopy := &jj.Commit{ChangeId: Revision, CommitId: Revision, IsWorkingCopy: true}
+	op := NewOperation(test.NewTestContext(commandRunner), workingCopy, 10)
+	op.Update(op.Init()())
+	op.Update(tea.KeyMsg{Type: tea.KeyDown})
+	op.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	_, cmd := op.Update(common.ActionMsg{Name: "revisions.commit"})
+	assert.Equal(t, common.StartCommitOperationMsg{Files: []string{"newfile.txt"}}, cmd())
+}
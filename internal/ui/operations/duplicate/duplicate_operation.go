@@ -59,7 +59,8 @@ func (r *Operation) Init() tea.Cmd {
 }
 
 func (r *Operation) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if msg, ok := msg.(tea.KeyMsg); ok {
+	switch msg.(type) {
+	case tea.KeyMsg, common.ActionMsg:
 		return r, r.HandleKey(msg)
 	}
 	return r, nil
@@ -69,18 +70,18 @@ func (r *Operation) View() string {
 	return ""
 }
 
-func (r *Operation) HandleKey(msg tea.KeyMsg) tea.Cmd {
+func (r *Operation) HandleKey(msg tea.Msg) tea.Cmd {
 	switch {
-	case key.Matches(msg, r.keyMap.Duplicate.Onto):
+	case common.Matches(msg, "duplicate.onto", r.keyMap.Duplicate.Onto):
 		r.Target = TargetDestination
-	case key.Matches(msg, r.keyMap.Duplicate.After):
+	case common.Matches(msg, "duplicate.after", r.keyMap.Duplicate.After):
 		r.Target = TargetAfter
-	case key.Matches(msg, r.keyMap.Duplicate.Before):
+	case common.Matches(msg, "duplicate.before", r.keyMap.Duplicate.Before):
 		r.Target = TargetBefore
-	case key.Matches(msg, r.keyMap.Apply):
+	case common.Matches(msg, "revisions.apply", r.keyMap.Apply):
 		target := targetToFlags[r.Target]
 		return r.context.RunCommand(jj.Duplicate(r.From, r.To.GetChangeId(), target), common.RefreshAndSelect(r.From.Last()), common.Close)
-	case key.Matches(msg, r.keyMap.Cancel):
+	case common.Matches(msg, "revisions.cancel", r.keyMap.Cancel):
 		return common.Close
 	}
 	return nil
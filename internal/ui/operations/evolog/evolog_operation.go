@@ -85,37 +85,37 @@ func (o *Operation) GetItemRenderer(index int) list.IItemRenderer {
 	}
 }
 
-func (o *Operation) HandleKey(msg tea.KeyMsg) tea.Cmd {
+func (o *Operation) HandleKey(msg tea.Msg) tea.Cmd {
 	switch o.mode {
 	case selectMode:
 		switch {
-		case key.Matches(msg, o.keyMap.Cancel):
+		case common.Matches(msg, "revisions.cancel", o.keyMap.Cancel):
 			return common.Close
-		case key.Matches(msg, o.keyMap.Up):
+		case common.Matches(msg, "revisions.up", o.keyMap.Up):
 			if o.cursor > 0 {
 				o.cursor--
 				return o.updateSelection()
 			}
-		case key.Matches(msg, o.keyMap.Down):
+		case common.Matches(msg, "revisions.down", o.keyMap.Down):
 			if o.cursor < len(o.rows)-1 {
 				o.cursor++
 				return o.updateSelection()
 			}
-		case key.Matches(msg, o.keyMap.Evolog.Diff):
+		case common.Matches(msg, "evolog.diff", o.keyMap.Evolog.Diff):
 			return func() tea.Msg {
 				selectedCommitId := o.getSelectedEvolog().CommitId
 				output, _ := o.context.RunCommandImmediate(jj.Diff(selectedCommitId, ""))
 				return common.ShowDiffMsg(output)
 			}
-		case key.Matches(msg, o.keyMap.Evolog.Restore):
+		case common.Matches(msg, "evolog.restore", o.keyMap.Evolog.Restore):
 			o.mode = restoreMode
 		}
 	case restoreMode:
 		switch {
-		case key.Matches(msg, o.keyMap.Cancel):
+		case common.Matches(msg, "revisions.cancel", o.keyMap.Cancel):
 			o.mode = selectMode
 			return nil
-		case key.Matches(msg, o.keyMap.Apply):
+		case common.Matches(msg, "revisions.apply", o.keyMap.Apply):
 			from := o.getSelectedEvolog().CommitId
 			into := o.target.GetChangeId()
 			return o.context.RunCommand(jj.RestoreEvolog(from, into), common.Close, common.Refresh)
@@ -154,7 +154,7 @@ func (o *Operation) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		o.rows = msg.rows
 		o.cursor = 0
 		return o, o.updateSelection()
-	case tea.KeyMsg:
+	case tea.KeyMsg, common.ActionMsg:
 		cmd := o.HandleKey(msg)
 		return o, cmd
 	}
@@ -24,7 +24,7 @@ func (r itemRenderer) Render(w io.Writer, width int) {
 
 		lw := strings.Builder{}
 		for _, segment := range segmentedLine.Gutter.Segments {
-			style := segment.Style
+			style := *segment.Style
 			fmt.Fprint(&lw, style.Render(segment.Text))
 		}
 
@@ -0,0 +1,169 @@
+package metadata
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/context"
+	"github.com/idursun/jjui/internal/ui/operations"
+)
+
+var _ operations.Operation = (*Operation)(nil)
+var _ common.Editable = (*Operation)(nil)
+
+type updateAuthorsMsg struct {
+	authors     []jj.Author
+	useMetaedit bool
+}
+
+type Operation struct {
+	context     *context.MainContext
+	keyMap      config.KeyMappings[key.Binding]
+	revisions   jj.SelectedRevisions
+	current     *jj.Commit
+	authors     []jj.Author
+	useMetaedit bool
+	inputs      []textinput.Model
+	focused     int
+	textStyle   lipgloss.Style
+	dimmedStyle lipgloss.Style
+	titleStyle  lipgloss.Style
+}
+
+func (o *Operation) IsEditing() bool {
+	return true
+}
+
+func (o *Operation) IsFocused() bool {
+	return true
+}
+
+func (o *Operation) ShortHelp() []key.Binding {
+	return []key.Binding{
+		o.keyMap.Cancel,
+		o.keyMap.Metadata.NextField,
+		key.NewBinding(key.WithKeys(o.keyMap.Apply.Keys()...), key.WithHelp(o.keyMap.Apply.Help().Key, "set author")),
+		o.keyMap.Metadata.ResetAuthor,
+	}
+}
+
+func (o *Operation) FullHelp() [][]key.Binding {
+	return [][]key.Binding{o.ShortHelp()}
+}
+
+func (o *Operation) SetSelectedRevision(commit *jj.Commit) {
+	o.current = commit
+}
+
+func (o *Operation) Render(commit *jj.Commit, pos operations.RenderPosition) string {
+	isSelected := commit != nil && o.current != nil && commit.GetChangeId() == o.current.GetChangeId()
+	if !isSelected || pos != operations.RenderPositionAfter {
+		return ""
+	}
+	return o.View()
+}
+
+func (o *Operation) Name() string {
+	return "metadata"
+}
+
+func (o *Operation) Init() tea.Cmd {
+	return o.load
+}
+
+// load reads the current authors of the revisions and the jj version to decide between `jj metaedit` and `jj describe`
+func (o *Operation) load() tea.Msg {
+	versionOutput, _ := o.context.RunCommandImmediate(jj.Version())
+	output, err := o.context.RunCommandImmediate(jj.GetAuthors(strings.Join(o.revisions.GetIds(), "|")))
+	if err != nil {
+		return common.CommandCompletedMsg{Output: string(output), Err: err}
+	}
+	return updateAuthorsMsg{
+		authors:     jj.ParseAuthorsOutput(string(output)),
+		useMetaedit: jj.SupportsMetaedit(string(versionOutput)),
+	}
+}
+
+func (o *Operation) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case updateAuthorsMsg:
+		o.authors = msg.authors
+		o.useMetaedit = msg.useMetaedit
+		if len(o.authors) > 0 {
+			o.inputs[0].SetValue(o.authors[0].Name)
+			o.inputs[1].SetValue(o.authors[0].Email)
+		}
+		return o, nil
+	case tea.KeyMsg, common.ActionMsg:
+		switch {
+		case common.Matches(msg, "revisions.cancel", o.keyMap.Cancel):
+			return o, common.Close
+		case common.Matches(msg, "metadata.next_field", o.keyMap.Metadata.NextField):
+			o.inputs[o.focused].Blur()
+			o.focused = (o.focused + 1) % len(o.inputs)
+			return o, o.inputs[o.focused].Focus()
+		case common.Matches(msg, "metadata.reset_author", o.keyMap.Metadata.ResetAuthor):
+			return o, o.context.RunCommand(jj.ResetAuthor(o.revisions, o.useMetaedit), common.Refresh, common.Close)
+		case common.Matches(msg, "revisions.apply", o.keyMap.Apply):
+			name := strings.TrimSpace(o.inputs[0].Value())
+			email := strings.TrimSpace(o.inputs[1].Value())
+			if name == "" || email == "" {
+				return o, nil
+			}
+			return o, o.context.RunCommand(jj.SetAuthor(o.revisions, name, email, o.useMetaedit), common.Refresh, common.Close)
+		}
+	}
+	var cmd tea.Cmd
+	o.inputs[o.focused], cmd = o.inputs[o.focused].Update(msg)
+	return o, cmd
+}
+
+func (o *Operation) View() string {
+	var b strings.Builder
+	b.WriteString(o.titleStyle.Render("Author metadata"))
+	b.WriteString("\n")
+	for _, author := range o.authors {
+		b.WriteString(o.textStyle.Render(fmt.Sprintf("%s %s <%s>", author.ChangeId, author.Name, author.Email)))
+		b.WriteString(o.dimmedStyle.Render(" " + author.Timestamp))
+		b.WriteString("\n")
+	}
+	b.WriteString(o.dimmedStyle.Render("Name:  "))
+	b.WriteString(o.inputs[0].View())
+	b.WriteString("\n")
+	b.WriteString(o.dimmedStyle.Render("Email: "))
+	b.WriteString(o.inputs[1].View())
+	return b.String()
+}
+
+func newInput(textStyle lipgloss.Style) textinput.Model {
+	t := textinput.New()
+	t.Prompt = ""
+	t.CharLimit = 120
+	t.TextStyle = textStyle
+	t.PromptStyle = textStyle
+	t.Cursor.TextStyle = textStyle
+	return t
+}
+
+func NewOperation(context *context.MainContext, revisions jj.SelectedRevisions) *Operation {
+	textStyle := common.DefaultPalette.Get("metadata text").Inline(true)
+	name := newInput(textStyle)
+	name.Focus()
+	email := newInput(textStyle)
+	return &Operation{
+		context:     context,
+		keyMap:      config.Current.GetKeyMap(),
+		revisions:   revisions,
+		inputs:      []textinput.Model{name, email},
+		textStyle:   textStyle,
+		dimmedStyle: common.DefaultPalette.Get("metadata dimmed"),
+		titleStyle:  common.DefaultPalette.Get("metadata title"),
+	}
+}
@@ -0,0 +1,61 @@
+package metadata
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/exp/teatest"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/test"
+)
+
+var commit = &jj.Commit{ChangeId: "a"}
+var revisions = jj.NewSelectedRevisions(commit, &jj.Commit{ChangeId: "b"})
+
+const authors = "a\tJane Doe\tjane@example.com\t2025-01-01 10:00:00 +00:00\nb\tJohn Doe\tjohn@example.com\t2025-01-02 10:00:00 +00:00\n"
+
+func Test_ShowsAuthorsAndSetsAuthorWithMetaedit(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.Version()).SetOutput([]byte("jj 0.31.0"))
+	commandRunner.Expect(jj.GetAuthors("a|b")).SetOutput([]byte(authors))
+	commandRunner.Expect(jj.SetAuthor(revisions, "Jane Doe", "jane@example.com", true))
+	defer commandRunner.Verify()
+
+	op := NewOperation(test.NewTestContext(commandRunner), revisions)
+	op.SetSelectedRevision(commit)
+
+	tm := teatest.NewTestModel(t, op)
+	teatest.WaitFor(t, tm.Output(), func(bts []byte) bool {
+		return bytes.Contains(bts, []byte("b John Doe <john@example.com>"))
+	})
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	teatest.WaitFor(t, tm.Output(), func(bts []byte) bool {
+		return commandRunner.IsVerified()
+	})
+	tm.Quit()
+	tm.WaitFinished(t, teatest.WithFinalTimeout(3*time.Second))
+}
+
+func Test_ResetAuthorWithDescribeOnOlderVersions(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.Version()).SetOutput([]byte("jj 0.28.2"))
+	commandRunner.Expect(jj.GetAuthors("a|b")).SetOutput([]byte(authors))
+	commandRunner.Expect(jj.ResetAuthor(revisions, false))
+	defer commandRunner.Verify()
+
+	op := NewOperation(test.NewTestContext(commandRunner), revisions)
+	op.SetSelectedRevision(commit)
+
+	tm := teatest.NewTestModel(t, op)
+	teatest.WaitFor(t, tm.Output(), func(bts []byte) bool {
+		return bytes.Contains(bts, []byte("Jane Doe"))
+	})
+	tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r"), Alt: true})
+	teatest.WaitFor(t, tm.Output(), func(bts []byte) bool {
+		return commandRunner.IsVerified()
+	})
+	tm.Quit()
+	tm.WaitFinished(t, teatest.WithFinalTimeout(3*time.Second))
+}
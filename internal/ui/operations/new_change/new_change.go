@@ -0,0 +1,294 @@
+package new_change
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/common"
+	appContext "github.com/idursun/jjui/internal/ui/context"
+	"github.com/idursun/jjui/internal/ui/operations"
+)
+
+type Target int
+
+const (
+	TargetDestination Target = iota
+	TargetAfter
+	TargetBefore
+	TargetInsert
+)
+
+type styles struct {
+	changeId     lipgloss.Style
+	dimmed       lipgloss.Style
+	targetMarker lipgloss.Style
+	sourceMarker lipgloss.Style
+}
+
+var _ operations.Operation = (*Operation)(nil)
+var _ common.Focusable = (*Operation)(nil)
+var _ common.Editable = (*Operation)(nil)
+
+type Operation struct {
+	context     *appContext.MainContext
+	Parents     []*jj.Commit
+	InsertStart *jj.Commit
+	To          *jj.Commit
+	Target      Target
+	keyMap      config.KeyMappings[key.Binding]
+	styles      styles
+	message     textinput.Model
+	prompting   bool
+}
+
+func (n *Operation) IsFocused() bool {
+	return true
+}
+
+// IsEditing is true while the message prompt is shown so that all keys go to the text input
+func (n *Operation) IsEditing() bool {
+	return n.prompting
+}
+
+func (n *Operation) Init() tea.Cmd {
+	return nil
+}
+
+func (n *Operation) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg.(type) {
+	case tea.KeyMsg, common.ActionMsg:
+		if n.prompting {
+			return n, n.handlePromptKey(msg)
+		}
+		return n, n.HandleKey(msg)
+	}
+	if n.prompting {
+		var cmd tea.Cmd
+		n.message, cmd = n.message.Update(msg)
+		return n, cmd
+	}
+	return n, nil
+}
+
+func (n *Operation) View() string {
+	return ""
+}
+
+func (n *Operation) HandleKey(msg tea.Msg) tea.Cmd {
+	switch {
+	case common.Matches(msg, "new_placement.onto", n.keyMap.NewPlacement.Onto):
+		n.Target = TargetDestination
+	case common.Matches(msg, "new_placement.after", n.keyMap.NewPlacement.After):
+		n.Target = TargetAfter
+	case common.Matches(msg, "new_placement.before", n.keyMap.NewPlacement.Before):
+		n.Target = TargetBefore
+	case common.Matches(msg, "new_placement.insert", n.keyMap.NewPlacement.Insert):
+		n.Target = TargetInsert
+		n.InsertStart = n.To
+	case common.Matches(msg, "new_placement.toggle_parent", n.keyMap.NewPlacement.ToggleParent):
+		if n.Target == TargetInsert {
+			n.Target = TargetDestination
+		}
+		n.toggleParent(n.To)
+	case common.Matches(msg, "revisions.apply", n.keyMap.Apply):
+		n.prompting = true
+		return n.message.Focus()
+	case common.Matches(msg, "revisions.cancel", n.keyMap.Cancel):
+		return common.Close
+	}
+	return nil
+}
+
+func (n *Operation) handlePromptKey(msg tea.Msg) tea.Cmd {
+	switch {
+	case common.Matches(msg, "revisions.cancel", n.keyMap.Cancel):
+		n.prompting = false
+		n.message.Blur()
+		return nil
+	case common.Matches(msg, "revisions.apply", n.keyMap.Apply):
+		return n.context.RunCommand(n.args(strings.TrimSpace(n.message.Value())), common.RefreshAndSelect("@"), common.Close)
+	}
+	var cmd tea.Cmd
+	n.message, cmd = n.message.Update(msg)
+	return cmd
+}
+
+func (n *Operation) args(message string) jj.CommandArgs {
+	switch n.Target {
+	case TargetAfter:
+		return jj.NewInsert(changeIds(n.parents()), nil, message)
+	case TargetBefore:
+		return jj.NewInsert(nil, changeIds(n.parents()), message)
+	case TargetInsert:
+		return jj.NewInsert([]string{n.InsertStart.GetChangeId()}, []string{n.To.GetChangeId()}, message)
+	default:
+		return jj.NewWithParents(jj.NewSelectedRevisions(n.parents()...), message)
+	}
+}
+
+// parents returns the toggled revisions followed by the revision under the cursor, the new change is
+// inserted before all of them when the target is before
+func (n *Operation) parents() []*jj.Commit {
+	parents := slices.Clone(n.Parents)
+	if n.To != nil && !n.isParent(n.To) {
+		parents = append(parents, n.To)
+	}
+	return parents
+}
+
+func changeIds(commits []*jj.Commit) []string {
+	var ids []string
+	for _, commit := range commits {
+		ids = append(ids, commit.GetChangeId())
+	}
+	return ids
+}
+
+func (n *Operation) isParent(commit *jj.Commit) bool {
+	return slices.ContainsFunc(n.Parents, func(c *jj.Commit) bool {
+		return c.GetChangeId() == commit.GetChangeId()
+	})
+}
+
+func (n *Operation) toggleParent(commit *jj.Commit) {
+	if commit == nil {
+		return
+	}
+	if n.isParent(commit) {
+		n.Parents = slices.DeleteFunc(n.Parents, func(c *jj.Commit) bool {
+			return c.GetChangeId() == commit.GetChangeId()
+		})
+		return
+	}
+	n.Parents = append(n.Parents, commit)
+}
+
+func (n *Operation) SetSelectedRevision(commit *jj.Commit) {
+	n.To = commit
+}
+
+func (n *Operation) ShortHelp() []key.Binding {
+	if n.prompting {
+		return []key.Binding{
+			n.keyMap.Cancel,
+			key.NewBinding(key.WithKeys(n.keyMap.Apply.Keys()...), key.WithHelp(n.keyMap.Apply.Help().Key, "create")),
+		}
+	}
+	return []key.Binding{
+		n.keyMap.Cancel,
+		n.keyMap.NewPlacement.After,
+		n.keyMap.NewPlacement.Before,
+		n.keyMap.NewPlacement.Onto,
+		n.keyMap.NewPlacement.Insert,
+		n.keyMap.NewPlacement.ToggleParent,
+	}
+}
+
+func (n *Operation) FullHelp() [][]key.Binding {
+	return [][]key.Binding{n.ShortHelp()}
+}
+
+func (n *Operation) Render(commit *jj.Commit, pos operations.RenderPosition) string {
+	if pos == operations.RenderBeforeChangeId {
+		if (n.Target == TargetDestination || n.Target == TargetAfter) && n.isParent(commit) {
+			return n.styles.sourceMarker.Render("<< parent >>")
+		}
+		if n.Target == TargetBefore && n.isParent(commit) {
+			return n.styles.sourceMarker.Render("<< child >>")
+		}
+		if n.Target == TargetInsert && n.InsertStart.GetChangeId() == commit.GetChangeId() {
+			return n.styles.sourceMarker.Render("<< after this >>")
+		}
+		if n.Target == TargetInsert && n.To.GetChangeId() == commit.GetChangeId() {
+			return n.styles.sourceMarker.Render("<< before this >>")
+		}
+		return ""
+	}
+	expectedPos := operations.RenderPositionBefore
+	if n.Target == TargetBefore || n.Target == TargetInsert {
+		expectedPos = operations.RenderPositionAfter
+	}
+
+	if pos != expectedPos {
+		return ""
+	}
+
+	isSelected := n.To != nil && n.To.GetChangeId() == commit.GetChangeId()
+	if !isSelected {
+		return ""
+	}
+
+	var marker string
+	switch n.Target {
+	case TargetInsert:
+		marker = lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			n.styles.targetMarker.Render("<< insert >>"),
+			n.styles.dimmed.Render(" new change between "),
+			n.styles.changeId.Render(n.InsertStart.GetChangeId()),
+			n.styles.dimmed.Render(" and "),
+			n.styles.changeId.Render(n.To.GetChangeId()),
+		)
+	case TargetAfter, TargetBefore:
+		ret := "after"
+		if n.Target == TargetBefore {
+			ret = "before"
+		}
+		marker = lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			n.styles.targetMarker.Render("<< "+ret+" >>"),
+			n.styles.dimmed.Render(" new change "+ret+" "),
+			n.styles.changeId.Render(strings.Join(changeIds(n.parents()), " ")),
+		)
+	default:
+		marker = lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			n.styles.targetMarker.Render("<< onto >>"),
+			n.styles.dimmed.Render(" new change onto "),
+			n.styles.changeId.Render(strings.Join(changeIds(n.parents()), " ")),
+		)
+	}
+	if !n.prompting {
+		return marker
+	}
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		marker,
+		lipgloss.JoinHorizontal(lipgloss.Left, n.styles.dimmed.Render("message: "), n.message.View()),
+	)
+}
+
+func (n *Operation) Name() string {
+	return "new"
+}
+
+func NewOperation(context *appContext.MainContext, parents jj.SelectedRevisions, target Target) *Operation {
+	styles := styles{
+		changeId:     common.DefaultPalette.Get("new change_id"),
+		dimmed:       common.DefaultPalette.Get("new dimmed"),
+		sourceMarker: common.DefaultPalette.Get("new source_marker"),
+		targetMarker: common.DefaultPalette.Get("new target_marker"),
+	}
+	textStyle := common.DefaultPalette.Get("new text").Inline(true)
+	message := textinput.New()
+	message.Prompt = ""
+	message.Placeholder = "(optional)"
+	message.TextStyle = textStyle
+	message.PromptStyle = textStyle
+	message.Cursor.TextStyle = textStyle
+	message.PlaceholderStyle = styles.dimmed
+	return &Operation{
+		context: context,
+		keyMap:  config.Current.GetKeyMap(),
+		Parents: parents.Revisions,
+		Target:  target,
+		styles:  styles,
+		message: message,
+	}
+}
@@ -0,0 +1,83 @@
+package new_change
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/exp/teatest"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/test"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	first  = &jj.Commit{ChangeId: "a"}
+	second = &jj.Commit{ChangeId: "b"}
+)
+
+func Test_InsertBetweenWithMessage(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.NewInsert([]string{"a"}, []string{"b"}, "wip"))
+	defer commandRunner.Verify()
+
+	op := NewOperation(test.NewTestContext(commandRunner), jj.SelectedRevisions{}, TargetDestination)
+	op.SetSelectedRevision(first)
+
+	op.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	op.SetSelectedRevision(second)
+
+	tm := teatest.NewTestModel(t, op)
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	tm.Type("wip")
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	teatest.WaitFor(t, tm.Output(), func(bts []byte) bool {
+		return commandRunner.IsVerified()
+	})
+	tm.Quit()
+	tm.WaitFinished(t, teatest.WithFinalTimeout(3*time.Second))
+}
+
+func Test_OntoToggledParents(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.NewWithParents(jj.NewSelectedRevisions(first, second), ""))
+	defer commandRunner.Verify()
+
+	op := NewOperation(test.NewTestContext(commandRunner), jj.SelectedRevisions{}, TargetDestination)
+	op.SetSelectedRevision(first)
+
+	op.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	op.SetSelectedRevision(second)
+
+	tm := teatest.NewTestModel(t, op)
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	teatest.WaitFor(t, tm.Output(), func(bts []byte) bool {
+		return commandRunner.IsVerified()
+	})
+	tm.Quit()
+	tm.WaitFinished(t, teatest.WithFinalTimeout(3*time.Second))
+}
+
+func Test_AfterToggledParents(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.NewInsert([]string{"a", "b"}, nil, ""))
+	defer commandRunner.Verify()
+
+	op := NewOperation(test.NewTestContext(commandRunner), jj.SelectedRevisions{}, TargetDestination)
+	op.SetSelectedRevision(first)
+
+	op.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	op.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	op.SetSelectedRevision(second)
+	assert.Equal(t, TargetAfter, op.Target, "expected toggling a parent to keep the target")
+
+	tm := teatest.NewTestModel(t, op)
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	teatest.WaitFor(t, tm.Output(), func(bts []byte) bool {
+		return commandRunner.IsVerified()
+	})
+	tm.Quit()
+	tm.WaitFinished(t, teatest.WithFinalTimeout(3*time.Second))
+}
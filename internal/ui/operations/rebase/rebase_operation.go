@@ -79,7 +79,8 @@ func (r *Operation) Init() tea.Cmd {
 }
 
 func (r *Operation) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if msg, ok := msg.(tea.KeyMsg); ok {
+	switch msg.(type) {
+	case tea.KeyMsg, common.ActionMsg:
 		return r, r.HandleKey(msg)
 	}
 	return r, nil
@@ -89,30 +90,30 @@ func (r *Operation) View() string {
 	return ""
 }
 
-func (r *Operation) HandleKey(msg tea.KeyMsg) tea.Cmd {
+func (r *Operation) HandleKey(msg tea.Msg) tea.Cmd {
 	switch {
-	case key.Matches(msg, r.keyMap.Rebase.Revision):
+	case common.Matches(msg, "rebase.revision", r.keyMap.Rebase.Revision):
 		r.Source = SourceRevision
-	case key.Matches(msg, r.keyMap.Rebase.Branch):
+	case common.Matches(msg, "rebase.branch", r.keyMap.Rebase.Branch):
 		r.Source = SourceBranch
-	case key.Matches(msg, r.keyMap.Rebase.Source):
+	case common.Matches(msg, "rebase.source", r.keyMap.Rebase.Source):
 		r.Source = SourceDescendants
-	case key.Matches(msg, r.keyMap.Rebase.Onto):
+	case common.Matches(msg, "rebase.onto", r.keyMap.Rebase.Onto):
 		r.Target = TargetDestination
-	case key.Matches(msg, r.keyMap.Rebase.After):
+	case common.Matches(msg, "rebase.after", r.keyMap.Rebase.After):
 		r.Target = TargetAfter
-	case key.Matches(msg, r.keyMap.Rebase.Before):
+	case common.Matches(msg, "rebase.before", r.keyMap.Rebase.Before):
 		r.Target = TargetBefore
-	case key.Matches(msg, r.keyMap.Rebase.Insert):
+	case common.Matches(msg, "rebase.insert", r.keyMap.Rebase.Insert):
 		r.Target = TargetInsert
 		r.InsertStart = r.To
-	case key.Matches(msg, r.keyMap.Rebase.Insert):
+	case common.Matches(msg, "rebase.insert", r.keyMap.Rebase.Insert):
 		r.Target = TargetInsert
 		r.InsertStart = r.To
-	case key.Matches(msg, r.keyMap.Rebase.SkipEmptied):
+	case common.Matches(msg, "rebase.skip_emptied", r.keyMap.Rebase.SkipEmptied):
 		r.SkipEmptied = !r.SkipEmptied
-	case key.Matches(msg, r.keyMap.Apply, r.keyMap.ForceApply):
-		ignoreImmutable := key.Matches(msg, r.keyMap.ForceApply)
+	case common.Matches(msg, "revisions.apply", r.keyMap.Apply) || common.Matches(msg, "revisions.force_apply", r.keyMap.ForceApply):
+		ignoreImmutable := common.Matches(msg, "revisions.force_apply", r.keyMap.ForceApply)
 		skipEmptied := r.SkipEmptied
 		if r.Target == TargetInsert {
 			return r.context.RunCommand(jj.RebaseInsert(r.From, r.InsertStart.GetChangeId(), r.To.GetChangeId(), skipEmptied, ignoreImmutable), common.RefreshAndSelect(r.From.Last()), common.Close)
@@ -121,7 +122,7 @@ func (r *Operation) HandleKey(msg tea.KeyMsg) tea.Cmd {
 			target := targetToFlags[r.Target]
 			return r.context.RunCommand(jj.Rebase(r.From, r.To.GetChangeId(), source, target, skipEmptied, ignoreImmutable), common.RefreshAndSelect(r.From.Last()), common.Close)
 		}
-	case key.Matches(msg, r.keyMap.Cancel):
+	case common.Matches(msg, "revisions.cancel", r.keyMap.Cancel):
 		return common.Close
 	}
 	return nil
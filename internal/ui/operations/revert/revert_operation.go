@@ -63,7 +63,8 @@ func (r *Operation) Init() tea.Cmd {
 }
 
 func (r *Operation) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if msg, ok := msg.(tea.KeyMsg); ok {
+	switch msg.(type) {
+	case tea.KeyMsg, common.ActionMsg:
 		return r, r.HandleKey(msg)
 	}
 	return r, nil
@@ -73,18 +74,18 @@ func (r *Operation) View() string {
 	return ""
 }
 
-func (r *Operation) HandleKey(msg tea.KeyMsg) tea.Cmd {
+func (r *Operation) HandleKey(msg tea.Msg) tea.Cmd {
 	switch {
-	case key.Matches(msg, r.keyMap.Revert.Onto):
+	case common.Matches(msg, "revert.onto", r.keyMap.Revert.Onto):
 		r.Target = TargetDestination
-	case key.Matches(msg, r.keyMap.Revert.After):
+	case common.Matches(msg, "revert.after", r.keyMap.Revert.After):
 		r.Target = TargetAfter
-	case key.Matches(msg, r.keyMap.Revert.Before):
+	case common.Matches(msg, "revert.before", r.keyMap.Revert.Before):
 		r.Target = TargetBefore
-	case key.Matches(msg, r.keyMap.Revert.Insert):
+	case common.Matches(msg, "revert.insert", r.keyMap.Revert.Insert):
 		r.Target = TargetInsert
 		r.InsertStart = r.To
-	case key.Matches(msg, r.keyMap.Apply):
+	case common.Matches(msg, "revisions.apply", r.keyMap.Apply):
 		if r.Target == TargetInsert {
 			return r.context.RunCommand(jj.RevertInsert(r.From, r.InsertStart.GetChangeId(), r.To.GetChangeId()), common.RefreshAndSelect(r.From.Last()), common.Close)
 		} else {
@@ -92,7 +93,7 @@ func (r *Operation) HandleKey(msg tea.KeyMsg) tea.Cmd {
 			target := targetToFlags[r.Target]
 			return r.context.RunCommand(jj.Revert(r.From, r.To.GetChangeId(), source, target), common.RefreshAndSelect(r.From.Last()), common.Close)
 		}
-	case key.Matches(msg, r.keyMap.Cancel):
+	case common.Matches(msg, "revisions.cancel", r.keyMap.Cancel):
 		return common.Close
 	}
 	return nil
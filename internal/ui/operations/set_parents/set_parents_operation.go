@@ -38,7 +38,8 @@ func (m *Model) Init() tea.Cmd {
 }
 
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if msg, ok := msg.(tea.KeyMsg); ok {
+	switch msg.(type) {
+	case tea.KeyMsg, common.ActionMsg:
 		return m, m.HandleKey(msg)
 	}
 	return m, nil
@@ -72,9 +73,9 @@ func (m *Model) SetSelectedRevision(commit *jj.Commit) {
 	m.current = commit
 }
 
-func (m *Model) HandleKey(msg tea.KeyMsg) tea.Cmd {
+func (m *Model) HandleKey(msg tea.Msg) tea.Cmd {
 	switch {
-	case key.Matches(msg, m.keyMap.ToggleSelect):
+	case common.Matches(msg, "revisions.toggle_select", m.keyMap.ToggleSelect):
 		if m.current.GetChangeId() == m.target.GetChangeId() {
 			return nil
 		}
@@ -92,7 +93,7 @@ func (m *Model) HandleKey(msg tea.KeyMsg) tea.Cmd {
 				m.toAdd[m.current.GetChangeId()] = true
 			}
 		}
-	case key.Matches(msg, m.keyMap.Apply):
+	case common.Matches(msg, "revisions.apply", m.keyMap.Apply):
 		if len(m.toAdd) == 0 && len(m.toRemove) == 0 {
 			return common.Close
 		}
@@ -109,7 +110,7 @@ func (m *Model) HandleKey(msg tea.KeyMsg) tea.Cmd {
 		}
 
 		return m.context.RunCommand(jj.SetParents(m.target.GetChangeId(), parentsToAdd, parentsToRemove), common.RefreshAndSelect(m.target.GetChangeId()), common.Close)
-	case key.Matches(msg, m.keyMap.Cancel):
+	case common.Matches(msg, "revisions.cancel", m.keyMap.Cancel):
 		return common.Close
 	}
 	return nil
@@ -0,0 +1,126 @@
+package sign
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/confirmation"
+	"github.com/idursun/jjui/internal/ui/context"
+	"github.com/idursun/jjui/internal/ui/operations"
+)
+
+var _ operations.Operation = (*Operation)(nil)
+var _ common.Editable = (*Operation)(nil)
+
+type Operation struct {
+	model             *confirmation.Model
+	current           *jj.Commit
+	context           *context.MainContext
+	selectedRevisions jj.SelectedRevisions
+}
+
+// rewrittenRevisionsMsg carries the revisions which are rewritten by signing or unsigning the selection
+type rewrittenRevisionsMsg struct {
+	ids []string
+}
+
+func (s *Operation) IsEditing() bool {
+	return true
+}
+
+func (s *Operation) Init() tea.Cmd {
+	return s.loadRewrittenRevisions()
+}
+
+func (s *Operation) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(rewrittenRevisionsMsg); ok {
+		s.model = s.newModel(msg.ids)
+		return s, nil
+	}
+	var cmd tea.Cmd
+	s.model, cmd = s.model.Update(msg)
+	return s, cmd
+}
+
+func (s *Operation) View() string {
+	return s.model.View()
+}
+
+func (s *Operation) ShortHelp() []key.Binding {
+	return s.model.ShortHelp()
+}
+
+func (s *Operation) FullHelp() [][]key.Binding {
+	return [][]key.Binding{s.ShortHelp()}
+}
+
+func (s *Operation) SetSelectedRevision(commit *jj.Commit) {
+	s.current = commit
+}
+
+func (s *Operation) Render(commit *jj.Commit, pos operations.RenderPosition) string {
+	isSelected := commit != nil && commit.GetChangeId() == s.current.GetChangeId()
+	if !isSelected || pos != operations.RenderPositionAfter {
+		return ""
+	}
+	return s.View()
+}
+
+func (s *Operation) Name() string {
+	return "sign"
+}
+
+// loadRewrittenRevisions lists the change ids of the selected revisions followed by their descendants,
+// since signing or unsigning a revision rewrites it and rebases everything on top of it
+func (s *Operation) loadRewrittenRevisions() tea.Cmd {
+	ids := s.selectedRevisions.GetIds()
+	revset := fmt.Sprintf("(%s)::", strings.Join(ids, "|"))
+	return s.context.Query(jj.GetIdsFromRevset(revset), func(output []byte, err error) tea.Msg {
+		if err != nil {
+			return rewrittenRevisionsMsg{ids: ids}
+		}
+		for _, line := range strings.Split(string(output), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || slices.Contains(ids, line) {
+				continue
+			}
+			ids = append(ids, line)
+		}
+		return rewrittenRevisionsMsg{ids: ids}
+	})
+}
+
+func (s *Operation) newModel(rewritten []string) *confirmation.Model {
+	message := fmt.Sprintf("Sign or unsign this revision? This will rewrite: %s", strings.Join(rewritten, " "))
+	if len(rewritten) > 1 {
+		message = fmt.Sprintf("Sign or unsign %d revisions? This will rewrite %d revisions: %s", len(s.selectedRevisions.Revisions), len(rewritten), strings.Join(rewritten, " "))
+	}
+	sign := func(ignoreImmutable bool) tea.Cmd {
+		return s.context.RunCommand(jj.Sign(s.selectedRevisions, ignoreImmutable), common.Refresh, common.Close)
+	}
+	unsign := func(ignoreImmutable bool) tea.Cmd {
+		return s.context.RunCommand(jj.Unsign(s.selectedRevisions, ignoreImmutable), common.Refresh, common.Close)
+	}
+	return confirmation.New(
+		[]string{message},
+		confirmation.WithAltOption("Sign", sign(false), sign(true), key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "sign"))),
+		confirmation.WithAltOption("Unsign", unsign(false), unsign(true), key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "unsign"))),
+		confirmation.WithOption("No", common.Close, key.NewBinding(key.WithKeys("n", "esc"), key.WithHelp("n/esc", "no"))),
+		confirmation.WithStylePrefix("sign"),
+	)
+}
+
+func NewOperation(context *context.MainContext, selectedRevisions jj.SelectedRevisions) *Operation {
+	op := &Operation{
+		context:           context,
+		selectedRevisions: selectedRevisions,
+	}
+	// the descendants are listed once they are loaded by Init
+	op.model = op.newModel(selectedRevisions.GetIds())
+	return op
+}
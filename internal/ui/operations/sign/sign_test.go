@@ -0,0 +1,55 @@
+package sign
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/exp/teatest"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/test"
+)
+
+var commit = &jj.Commit{ChangeId: "a"}
+var revisions = jj.NewSelectedRevisions(commit)
+
+func Test_ListsRewrittenRevisionsAndSigns(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetIdsFromRevset("(a)::")).SetOutput([]byte("b\na\n"))
+	commandRunner.Expect(jj.Sign(revisions, false))
+	defer commandRunner.Verify()
+
+	model := NewOperation(test.NewTestContext(commandRunner), revisions)
+	model.SetSelectedRevision(commit)
+
+	tm := teatest.NewTestModel(t, model)
+	teatest.WaitFor(t, tm.Output(), func(bts []byte) bool {
+		return bytes.Contains(bts, []byte("rewrite 2 revisions: a b"))
+	})
+
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	teatest.WaitFor(t, tm.Output(), func(bts []byte) bool {
+		return commandRunner.IsVerified()
+	})
+	tm.Quit()
+	tm.WaitFinished(t, teatest.WithFinalTimeout(3*time.Second))
+}
+
+func Test_Unsign(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetIdsFromRevset("(a)::")).SetOutput([]byte("a\n"))
+	commandRunner.Expect(jj.Unsign(revisions, false))
+	defer commandRunner.Verify()
+
+	model := NewOperation(test.NewTestContext(commandRunner), revisions)
+	model.SetSelectedRevision(commit)
+
+	tm := teatest.NewTestModel(t, model)
+	tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	teatest.WaitFor(t, tm.Output(), func(bts []byte) bool {
+		return commandRunner.IsVerified()
+	})
+	tm.Quit()
+	tm.WaitFinished(t, teatest.WithFinalTimeout(3*time.Second))
+}
@@ -43,7 +43,8 @@ func (s *Operation) Init() tea.Cmd {
 }
 
 func (s *Operation) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if msg, ok := msg.(tea.KeyMsg); ok {
+	switch msg.(type) {
+	case tea.KeyMsg, common.ActionMsg:
 		return s, s.HandleKey(msg)
 	}
 	return s, nil
@@ -53,16 +54,16 @@ func (s *Operation) View() string {
 	return ""
 }
 
-func (s *Operation) HandleKey(msg tea.KeyMsg) tea.Cmd {
+func (s *Operation) HandleKey(msg tea.Msg) tea.Cmd {
 	switch {
-	case key.Matches(msg, s.keyMap.Apply, s.keyMap.ForceApply):
-		ignoreImmutable := key.Matches(msg, s.keyMap.ForceApply)
+	case common.Matches(msg, "revisions.apply", s.keyMap.Apply) || common.Matches(msg, "revisions.force_apply", s.keyMap.ForceApply):
+		ignoreImmutable := common.Matches(msg, "revisions.force_apply", s.keyMap.ForceApply)
 		return tea.Batch(common.Close, s.context.RunInteractiveCommand(jj.Squash(s.from, s.current.GetChangeId(), s.files, s.keepEmptied, s.interactive, ignoreImmutable), common.RefreshAndSelect(s.current.GetChangeId())))
-	case key.Matches(msg, s.keyMap.Cancel):
+	case common.Matches(msg, "revisions.cancel", s.keyMap.Cancel):
 		return common.Close
-	case key.Matches(msg, s.keyMap.Squash.KeepEmptied):
+	case common.Matches(msg, "squash.keep_emptied", s.keyMap.Squash.KeepEmptied):
 		s.keepEmptied = !s.keepEmptied
-	case key.Matches(msg, s.keyMap.Squash.Interactive):
+	case common.Matches(msg, "squash.interactive", s.keyMap.Squash.Interactive):
 		s.interactive = !s.interactive
 	}
 	return nil
@@ -63,27 +63,33 @@ func (m *Model) Init() tea.Cmd {
 
 func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case common.ConfigReloadedMsg:
+		m.keymap = config.Current.GetKeyMap()
+		m.textStyle = common.DefaultPalette.Get("oplog text")
+		m.selectedStyle = common.DefaultPalette.Get("oplog selected")
+		m.renderer.Reset()
+		return m, nil
 	case updateOpLogMsg:
 		m.rows = msg.Rows
 		m.renderer.Reset()
-	case tea.KeyMsg:
+	case tea.KeyMsg, common.ActionMsg:
 		switch {
-		case key.Matches(msg, m.keymap.Cancel):
+		case common.Matches(msg, "revisions.cancel", m.keymap.Cancel):
 			return m, common.Close
-		case key.Matches(msg, m.keymap.Up):
+		case common.Matches(msg, "revisions.up", m.keymap.Up):
 			if m.cursor > 0 {
 				m.cursor--
 			}
-		case key.Matches(msg, m.keymap.Down):
+		case common.Matches(msg, "revisions.down", m.keymap.Down):
 			if m.cursor < len(m.rows)-1 {
 				m.cursor++
 			}
-		case key.Matches(msg, m.keymap.Diff):
+		case common.Matches(msg, "revisions.diff", m.keymap.Diff):
 			return m, func() tea.Msg {
 				output, _ := m.context.RunCommandImmediate(jj.OpShow(m.rows[m.cursor].OperationId))
 				return common.ShowDiffMsg(output)
 			}
-		case key.Matches(msg, m.keymap.OpLog.Restore):
+		case common.Matches(msg, "oplog.restore", m.keymap.OpLog.Restore):
 			return m, tea.Batch(common.Close, m.context.RunCommand(jj.OpRestore(m.rows[m.cursor].OperationId), common.Refresh))
 		}
 	}
@@ -94,6 +100,13 @@ func (m *Model) updateSelection() tea.Cmd {
 	if m.rows == nil {
 		return nil
 	}
+	var adjacent []context.SelectedItem
+	for _, i := range []int{m.cursor - 1, m.cursor + 1} {
+		if i >= 0 && i < len(m.rows) {
+			adjacent = append(adjacent, context.SelectedOperation{OperationId: m.rows[i].OperationId})
+		}
+	}
+	m.context.AdjacentItems = adjacent
 	return m.context.SetSelectedItem(context.SelectedOperation{OperationId: m.rows[m.cursor].OperationId})
 }
 
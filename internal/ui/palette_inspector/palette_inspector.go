@@ -0,0 +1,150 @@
+package palette_inspector
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/context"
+)
+
+const embeddedTheme = ""
+
+// Model lists every style key of the palette with a sample rendered in the resolved style
+// and the layers which contributed to it. It also switches between the themes in the themes directory.
+type Model struct {
+	*common.Sizeable
+	context   *context.MainContext
+	keyMap    config.KeyMappings[key.Binding]
+	selectors []string
+	cursor    int
+	themes    []string
+	theme     int
+}
+
+func (m *Model) ShortHelp() []key.Binding {
+	return []key.Binding{m.keyMap.Up, m.keyMap.Down, m.keyMap.Palette.PrevTheme, m.keyMap.Palette.NextTheme, m.keyMap.Cancel}
+}
+
+func (m *Model) FullHelp() [][]key.Binding {
+	return [][]key.Binding{m.ShortHelp()}
+}
+
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case common.ConfigReloadedMsg:
+		m.keyMap = config.Current.GetKeyMap()
+		m.selectors = common.DefaultPalette.Selectors()
+		m.cursor = min(m.cursor, max(len(m.selectors)-1, 0))
+	case tea.KeyMsg, common.ActionMsg:
+		switch {
+		case common.Matches(msg, "revisions.cancel", m.keyMap.Cancel), common.Matches(msg, "revisions.palette", m.keyMap.Palette.Mode):
+			return m, common.Close
+		case common.Matches(msg, "revisions.up", m.keyMap.Up):
+			m.cursor = max(m.cursor-1, 0)
+		case common.Matches(msg, "revisions.down", m.keyMap.Down):
+			m.cursor = min(m.cursor+1, max(len(m.selectors)-1, 0))
+		case common.Matches(msg, "palette.next_theme", m.keyMap.Palette.NextTheme):
+			return m, m.switchTheme(1)
+		case common.Matches(msg, "palette.prev_theme", m.keyMap.Palette.PrevTheme):
+			return m, m.switchTheme(-1)
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) switchTheme(delta int) tea.Cmd {
+	if len(m.themes) < 2 {
+		return nil
+	}
+	m.theme = (m.theme + delta + len(m.themes)) % len(m.themes)
+	name := m.themes[m.theme]
+	return func() tea.Msg {
+		return common.SwitchThemeMsg(name)
+	}
+}
+
+func themeLabel(name string) string {
+	if name == embeddedTheme {
+		return "(embedded)"
+	}
+	return name
+}
+
+func (m *Model) View() string {
+	palette := common.DefaultPalette
+	border := palette.GetBorder("palette border", lipgloss.NormalBorder()).Padding(0, 1)
+	title := palette.Get("palette title")
+	text := palette.Get("palette text")
+	dimmed := palette.Get("palette dimmed")
+	selected := palette.Get("palette selected")
+
+	width := max(m.Width-border.GetHorizontalFrameSize(), 20)
+	height := max(m.Height-border.GetVerticalFrameSize(), 5)
+
+	header := title.Render("Theme: ") + text.Render(themeLabel(m.themes[m.theme]))
+	if len(m.themes) > 1 {
+		header += dimmed.Render(fmt.Sprintf("  (%d of %d, %s/%s to switch)", m.theme+1, len(m.themes),
+			m.keyMap.Palette.PrevTheme.Help().Key, m.keyMap.Palette.NextTheme.Help().Key))
+	}
+	lines := []string{header, ""}
+
+	visible := height - len(lines)
+	start := 0
+	if m.cursor >= visible {
+		start = m.cursor - visible + 1
+	}
+	end := min(start+visible, len(m.selectors))
+	for i := start; i < end; i++ {
+		selector := m.selectors[i]
+		sample := palette.Get(selector).Render(" Sample ")
+		name := text.Render(" " + selector)
+		if i == m.cursor {
+			name = selected.Render(" " + selector)
+		}
+		var origins []string
+		for _, c := range palette.Explain(selector) {
+			origin := c.Origin
+			if origin == "" {
+				origin = "?"
+			}
+			origins = append(origins, fmt.Sprintf("%s ← %s", c.Key, origin))
+		}
+		explanation := "not styled"
+		if len(origins) > 0 {
+			explanation = strings.Join(origins, ", ")
+		}
+		line := sample + name + dimmed.Render("  "+explanation)
+		lines = append(lines, lipgloss.NewStyle().MaxWidth(width).Render(line))
+	}
+	content := lipgloss.Place(width, height, 0, 0, strings.Join(lines, "\n"), lipgloss.WithWhitespaceBackground(text.GetBackground()))
+	return border.Render(content)
+}
+
+func New(ctx *context.MainContext, width int, height int) *Model {
+	themes := []string{embeddedTheme}
+	if names, err := config.ListThemes(); err == nil {
+		themes = append(themes, names...)
+	}
+	current := config.Current.ThemeName(ctx.DarkBackground)
+	if !slices.Contains(themes, current) {
+		themes = append(themes, current)
+	}
+	return &Model{
+		Sizeable:  &common.Sizeable{Width: width, Height: height},
+		context:   ctx,
+		keyMap:    config.Current.GetKeyMap(),
+		selectors: common.DefaultPalette.Selectors(),
+		themes:    themes,
+		theme:     slices.Index(themes, current),
+	}
+}
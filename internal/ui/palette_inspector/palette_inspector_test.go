@@ -0,0 +1,47 @@
+package palette_inspector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaletteInspector_SwitchTheme(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("JJUI_CONFIG_DIR", dir)
+	themes := filepath.Join(dir, "jjui", "themes")
+	require.NoError(t, os.MkdirAll(themes, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(themes, "nord.toml"), []byte(`title = "blue"`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(themes, "solarized.toml"), []byte(`title = "yellow"`), 0o644))
+
+	ctx := test.NewTestContext(test.NewTestCommandRunner(t))
+	model := New(ctx, 80, 20)
+	assert.Equal(t, []string{"", "nord", "solarized"}, model.themes)
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRight})
+	require.NotNil(t, cmd)
+	assert.Equal(t, common.SwitchThemeMsg("nord"), cmd())
+
+	model.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	assert.Equal(t, common.SwitchThemeMsg("solarized"), cmd())
+}
+
+func TestPaletteInspector_View(t *testing.T) {
+	ctx := test.NewTestContext(test.NewTestCommandRunner(t))
+	common.DefaultPalette.Reset()
+	common.DefaultPalette.UpdateFrom("embedded theme", map[string]config.Color{"revisions selected": {Fg: "cyan"}})
+
+	model := New(ctx, 120, 200)
+	view := model.View()
+	assert.Contains(t, view, "revisions selected")
+	assert.Contains(t, view, "embedded theme")
+	assert.Contains(t, view, "Sample")
+}
@@ -0,0 +1,67 @@
+package preview
+
+import (
+	"container/list"
+	"strings"
+)
+
+// cache keeps the most recently used previews, the least recently used one is dropped when it is full
+type cache struct {
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key     string
+	content string
+}
+
+func newCache(capacity int) *cache {
+	return &cache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  map[string]*list.Element{},
+	}
+}
+
+func (c *cache) get(key string) (string, bool) {
+	element, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*cacheEntry).content, true
+}
+
+func (c *cache) put(key string, content string) {
+	if c.capacity <= 0 {
+		return
+	}
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*cacheEntry).content = content
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, content: content})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// dropCommits removes the previews of the commits, the keys of the previews start with their commit ids
+func (c *cache) dropCommits(commitIds []string) {
+	for _, commitId := range commitIds {
+		if commitId == "" {
+			continue
+		}
+		for key, element := range c.entries {
+			if strings.HasPrefix(key, commitId+"\x00") {
+				c.order.Remove(element)
+				delete(c.entries, key)
+			}
+		}
+	}
+}
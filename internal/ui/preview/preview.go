@@ -1,7 +1,13 @@
 package preview
 
 import (
-	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -9,10 +15,11 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/idursun/jjui/internal/config"
 	"github.com/idursun/jjui/internal/jj"
 	"github.com/idursun/jjui/internal/ui/common"
-	"github.com/idursun/jjui/internal/ui/context"
+	appContext "github.com/idursun/jjui/internal/ui/context"
 )
 
 type viewRange struct {
@@ -30,9 +37,25 @@ type Model struct {
 	help                    help.Model
 	content                 string
 	contentLineCount        int
-	context                 *context.MainContext
+	lines                   []string
+	rows                    []string
+	rowOf                   []int
+	widest                  int
+	wrap                    bool
+	xOffset                 int
+	search                  string
+	views                   map[string]int
+	label                   string
+	matches                 []match
+	current                 int
+	shown                   string
+	context                 *appContext.MainContext
+	cache                   *cache
+	cancel                  context.CancelFunc
 	keyMap                  config.KeyMappings[key.Binding]
 	borderStyle             lipgloss.Style
+	matchStyle              lipgloss.Style
+	currentMatchStyle       lipgloss.Style
 }
 
 const DebounceTime = 50 * time.Millisecond
@@ -52,11 +75,69 @@ type refreshPreviewContentMsg struct {
 	Tag int
 }
 
+// previewLoadedMsg carries the output of a preview command run in the background
+type previewLoadedMsg struct {
+	tag     int
+	key     string
+	item    string
+	view    string
+	content string
+	err     error
+}
+
+// prefetchedMsg carries the preview of a row next to the selected one
+type prefetchedMsg struct {
+	key     string
+	content string
+}
+
+// previewRequest is the command that renders the preview of an item and the cache key of its output.
+// item identifies the previewed item and view regardless of its commit so that refreshing its preview
+// keeps the scroll position. view is the name of the active view when there are others to cycle to.
+// A shell command line is run with the placeholders in env instead of jj with args, and sized commands
+// depend on the size of the pane.
+type previewRequest struct {
+	args  []string
+	shell string
+	env   map[string]string
+	sized bool
+	key   string
+	item  string
+	view  string
+	// commitId is the commit of the item, the key starts with it
+	commitId string
+}
+
+// resizedMsg reloads a preview that depends on the size of the pane after the pane is resized
+type resizedMsg struct{}
+
+// Resized reloads the preview if its command uses the $width or $height placeholders
+func Resized() tea.Msg {
+	return previewMsg{msg: resizedMsg{}}
+}
+
+const (
+	WidthPlaceholder  = "$width"
+	HeightPlaceholder = "$height"
+)
+
 func (m *Model) SetHeight(h int) {
 	m.viewRange.end = min(m.viewRange.start+h-3, m.contentLineCount)
 	m.Height = h
 }
 
+func (m *Model) SetWidth(w int) {
+	if m.Width == w {
+		return
+	}
+	m.Width = w
+	if m.wrap {
+		top := m.lineAt(m.viewRange.start)
+		m.layout()
+		m.scrollTo(m.rowOf[top])
+	}
+}
+
 func (m *Model) Init() tea.Cmd {
 	return nil
 }
@@ -91,65 +172,199 @@ func (m *Model) WindowPercentage() float64 {
 	return m.previewWindowPercentage
 }
 
-func (m *Model) updatePreviewContent(content string) {
+// updatePreviewContent shows the preview of item, the scroll position and the search are kept when it is
+// the item that is already shown
+func (m *Model) updatePreviewContent(item string, content string) {
+	keep := item != "" && item == m.shown
+	m.shown = item
 	m.content = content
-	m.contentLineCount = lipgloss.Height(m.content)
+	m.lines = strings.Split(strings.ReplaceAll(content, "\r", ""), "\n")
+	m.matches = findMatches(m.lines, m.search)
+	if !keep || m.current >= len(m.matches) {
+		m.current = 0
+	}
+	m.layout()
+	if keep {
+		m.scrollTo(m.viewRange.start)
+		return
+	}
+	m.xOffset = 0
 	m.reset()
 }
 
+// layout splits the lines into the rows that are shown, highlighting the matches and wrapping long
+// lines when wrap is on
+func (m *Model) layout() {
+	width := max(m.Width-2, 1)
+	m.rows, m.rowOf, m.widest = m.rows[:0], m.rowOf[:0], 0
+	next := 0
+	for i, line := range m.lines {
+		m.rowOf = append(m.rowOf, len(m.rows))
+		first := next
+		for next < len(m.matches) && m.matches[next].line == i {
+			next++
+		}
+		line = highlight(line, m.matches[first:next], m.current-first, m.matchStyle, m.currentMatchStyle)
+		if m.wrap {
+			m.rows = append(m.rows, strings.Split(ansi.Wrap(line, width, " "), "\n")...)
+			continue
+		}
+		m.widest = max(m.widest, ansi.StringWidth(line))
+		m.rows = append(m.rows, line)
+	}
+	m.contentLineCount = len(m.rows)
+}
+
+// lineAt returns the line that row belongs to
+func (m *Model) lineAt(row int) int {
+	line, found := slices.BinarySearch(m.rowOf, row)
+	if !found {
+		line--
+	}
+	return max(line, 0)
+}
+
+// scrollTo makes row the first visible row, as far as there are rows to fill the view
+func (m *Model) scrollTo(row int) {
+	page := max(m.Height-3, 0)
+	m.viewRange.start = max(min(row, m.contentLineCount-page), 0)
+	m.viewRange.end = min(m.viewRange.start+page, m.contentLineCount)
+}
+
+// scrollLeft moves the view horizontally by delta cells
+func (m *Model) scrollLeft(delta int) {
+	if m.wrap {
+		return
+	}
+	m.xOffset = max(min(m.xOffset-delta, m.widest-(m.Width-2)), 0)
+}
+
+// jumpToMatch makes the current match the selected one and scrolls it into view
+func (m *Model) jumpToMatch(current int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.current = (current + len(m.matches)) % len(m.matches)
+	m.layout()
+	match := m.matches[m.current]
+	row := m.rowOf[match.line]
+	if m.wrap {
+		row += match.start / max(m.Width-2, 1)
+	}
+	if row < m.viewRange.start || row > m.viewRange.end {
+		m.scrollTo(row - (m.viewRange.end-m.viewRange.start)/2)
+	}
+	if width := m.Width - 2; !m.wrap && (match.start < m.xOffset || match.end > m.xOffset+width) {
+		m.xOffset = 0
+		m.scrollLeft(-(match.start - width/4))
+	}
+}
+
+// startSearch highlights the matches of term and jumps to the first one after the top of the view
+func (m *Model) startSearch(term string) {
+	m.search = term
+	m.matches = findMatches(m.lines, term)
+	top := m.lineAt(m.viewRange.start)
+	first, _ := slices.BinarySearchFunc(m.matches, top, func(match match, line int) int {
+		return match.line - line
+	})
+	if first == len(m.matches) {
+		first = 0
+	}
+	m.current = first
+	m.layout()
+	m.jumpToMatch(first)
+}
+
 func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 	if k, ok := msg.(previewMsg); ok {
 		msg = k.msg
 	}
 	switch msg := msg.(type) {
-	case common.SelectionChangedMsg, common.RefreshMsg:
+	case common.ConfigReloadedMsg:
+		m.keyMap = config.Current.GetKeyMap()
+		m.borderStyle = newBorderStyle()
+		m.matchStyle, m.currentMatchStyle = newMatchStyles()
+		m.layout()
+		return m, nil
+	case common.ShowPreviewContentMsg:
+		// a pending refresh would replace the content
 		m.tag++
-		tag := m.tag
-		return m, tea.Tick(DebounceTime, func(t time.Time) tea.Msg {
-			return refreshPreviewContentMsg{Tag: tag}
-		})
+		m.label = ""
+		m.updatePreviewContent("", string(msg))
+		return m, nil
+	case common.SelectionChangedMsg, common.RefreshMsg:
+		return m, m.debounce()
+	case common.CommitsChangedMsg:
+		// the commit id does not cover everything a preview shows, e.g. bookmarks
+		m.cache.dropCommits(msg.CommitIds)
+		if request, ok := m.request(m.context.SelectedItem); ok && slices.Contains(msg.CommitIds, request.commitId) {
+			return m, m.debounce()
+		}
+	case tea.WindowSizeMsg, resizedMsg:
+		if request, ok := m.request(m.context.SelectedItem); ok && request.sized {
+			return m, m.debounce()
+		}
 	case refreshPreviewContentMsg:
 		if m.tag == msg.Tag {
-			switch msg := m.context.SelectedItem.(type) {
-			case context.SelectedFile:
-				replacements := map[string]string{
-					jj.RevsetPlaceholder:   m.context.CurrentRevset,
-					jj.ChangeIdPlaceholder: msg.ChangeId,
-					jj.CommitIdPlaceholder: msg.CommitId,
-					jj.FilePlaceholder:     msg.File,
-				}
-				output, _ := m.context.RunCommandImmediate(jj.TemplatedArgs(config.Current.Preview.FileCommand, replacements))
-				m.updatePreviewContent(string(output))
-			case context.SelectedRevision:
-				replacements := map[string]string{
-					jj.RevsetPlaceholder:   m.context.CurrentRevset,
-					jj.ChangeIdPlaceholder: msg.ChangeId,
-					jj.CommitIdPlaceholder: msg.CommitId,
-				}
-				output, _ := m.context.RunCommandImmediate(jj.TemplatedArgs(config.Current.Preview.RevisionCommand, replacements))
-				m.updatePreviewContent(string(output))
-			case context.SelectedOperation:
-				replacements := map[string]string{
-					jj.RevsetPlaceholder:      m.context.CurrentRevset,
-					jj.OperationIdPlaceholder: msg.OperationId,
-				}
-				output, _ := m.context.RunCommandImmediate(jj.TemplatedArgs(config.Current.Preview.OplogCommand, replacements))
-				m.updatePreviewContent(string(output))
-			}
+			return m, m.load(msg.Tag)
+		}
+	case previewLoadedMsg:
+		if msg.err == nil {
+			m.cache.put(msg.key, msg.content)
+		}
+		if m.tag != msg.tag || errors.Is(msg.err, context.Canceled) {
+			return m, nil
+		}
+		m.label = msg.view
+		if msg.err != nil {
+			m.updatePreviewContent("", msg.err.Error())
+			return m, nil
 		}
-	case tea.KeyMsg:
+		m.updatePreviewContent(msg.item, msg.content)
+		return m, m.prefetch()
+	case prefetchedMsg:
+		m.cache.put(msg.key, msg.content)
+	case searchMsg:
+		m.startSearch(string(msg))
+	case tea.KeyMsg, common.ActionMsg:
 		switch {
-		case key.Matches(msg, m.keyMap.Preview.ScrollDown):
+		case common.Matches(msg, "preview.search", m.keyMap.Preview.Search):
+			return m, func() tea.Msg {
+				return common.PromptMsg{
+					Prompt: "preview search",
+					Accept: func(term string) tea.Cmd {
+						return PreviewCmd(searchMsg(term))
+					},
+				}
+			}
+		case common.Matches(msg, "preview.cycle_view", m.keyMap.Preview.CycleView):
+			return m, m.cycleView()
+		case common.Matches(msg, "preview.search_next", m.keyMap.Preview.SearchNext):
+			m.jumpToMatch(m.current + 1)
+		case common.Matches(msg, "preview.search_prev", m.keyMap.Preview.SearchPrev):
+			m.jumpToMatch(m.current - 1)
+		case common.Matches(msg, "preview.toggle_wrap", m.keyMap.Preview.ToggleWrap):
+			top := m.lineAt(m.viewRange.start)
+			m.wrap = !m.wrap
+			m.xOffset = 0
+			m.layout()
+			m.scrollTo(m.rowOf[top])
+		case common.Matches(msg, "preview.scroll_left", m.keyMap.Preview.ScrollLeft):
+			m.scrollLeft((m.Width - 2) / 2)
+		case common.Matches(msg, "preview.scroll_right", m.keyMap.Preview.ScrollRight):
+			m.scrollLeft(-(m.Width - 2) / 2)
+		case common.Matches(msg, "preview.scroll_down", m.keyMap.Preview.ScrollDown):
 			if m.viewRange.end < m.contentLineCount {
 				m.viewRange.start++
 				m.viewRange.end++
 			}
-		case key.Matches(msg, m.keyMap.Preview.ScrollUp):
+		case common.Matches(msg, "preview.scroll_up", m.keyMap.Preview.ScrollUp):
 			if m.viewRange.start > 0 {
 				m.viewRange.start--
 				m.viewRange.end--
 			}
-		case key.Matches(msg, m.keyMap.Preview.HalfPageDown):
+		case common.Matches(msg, "preview.half_page_down", m.keyMap.Preview.HalfPageDown):
 			contentHeight := m.contentLineCount
 			halfPageSize := m.Height / 2
 			if halfPageSize+m.viewRange.end > contentHeight {
@@ -158,7 +373,7 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 
 			m.viewRange.start += halfPageSize
 			m.viewRange.end += halfPageSize
-		case key.Matches(msg, m.keyMap.Preview.HalfPageUp):
+		case common.Matches(msg, "preview.half_page_up", m.keyMap.Preview.HalfPageUp):
 			halfPageSize := min(m.Height/2, m.viewRange.start)
 			m.viewRange.start -= halfPageSize
 			m.viewRange.end -= halfPageSize
@@ -167,26 +382,249 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 	return m, nil
 }
 
-func (m *Model) View() string {
-	var w strings.Builder
-	scanner := bufio.NewScanner(strings.NewReader(m.content))
-	current := 0
-	for scanner.Scan() {
-		line := scanner.Text()
-		line = strings.ReplaceAll(line, "\r", "")
-		if current >= m.viewRange.start && current <= m.viewRange.end {
-			if current > m.viewRange.start {
-				w.WriteString("\n")
+// debounce reloads the preview once the selection or the size has settled
+func (m *Model) debounce() tea.Cmd {
+	m.tag++
+	tag := m.tag
+	return tea.Tick(DebounceTime, func(t time.Time) tea.Msg {
+		return refreshPreviewContentMsg{Tag: tag}
+	})
+}
+
+// load shows the preview of the selected item from the cache or starts its command in the background,
+// cancelling the command of the previous selection
+func (m *Model) load(tag int) tea.Cmd {
+	request, ok := m.request(m.context.SelectedItem)
+	if !ok {
+		return nil
+	}
+	if content, ok := m.cache.get(request.key); ok {
+		m.label = request.view
+		m.updatePreviewContent(request.item, content)
+		return m.prefetch()
+	}
+	ctx := m.restart()
+	runner, location := m.context.CommandRunner, m.context.Location
+	return func() tea.Msg {
+		content, err := run(ctx, runner, location, request)
+		return previewLoadedMsg{tag: tag, key: request.key, item: request.item, view: request.view, content: content, err: err}
+	}
+}
+
+// prefetch loads the previews of the rows next to the selected one, one at a time so that they do not
+// compete with each other. They are cancelled along with the next load.
+func (m *Model) prefetch() tea.Cmd {
+	if !config.Current.Preview.Prefetch {
+		return nil
+	}
+	var requests []previewRequest
+	for _, item := range m.context.AdjacentItems {
+		if request, ok := m.request(item); ok {
+			if _, cached := m.cache.get(request.key); !cached {
+				requests = append(requests, request)
 			}
-			w.WriteString(lipgloss.NewStyle().MaxWidth(m.Width - 2).Render(line))
 		}
-		current++
-		if current > m.viewRange.end {
-			break
+	}
+	if len(requests) == 0 {
+		return nil
+	}
+	ctx := m.restart()
+	runner, location := m.context.CommandRunner, m.context.Location
+	var cmds []tea.Cmd
+	for _, request := range requests {
+		cmds = append(cmds, func() tea.Msg {
+			content, err := run(ctx, runner, location, request)
+			if err != nil {
+				return nil
+			}
+			return prefetchedMsg{key: request.key, content: content}
+		})
+	}
+	return tea.Sequence(cmds...)
+}
+
+// restart cancels the running preview commands and returns the context for the next ones
+func (m *Model) restart() context.Context {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	return ctx
+}
+
+func (m *Model) request(item appContext.SelectedItem) (previewRequest, bool) {
+	replacements := map[string]string{
+		jj.RevsetPlaceholder: m.context.CurrentRevset,
+	}
+	var commitId, id string
+	switch item := item.(type) {
+	case appContext.SelectedFile:
+		replacements[jj.ChangeIdPlaceholder] = item.ChangeId
+		replacements[jj.CommitIdPlaceholder] = item.CommitId
+		replacements[jj.FilePlaceholder] = item.File
+		commitId, id = item.CommitId, item.ChangeId+"\x00"+item.File
+	case appContext.SelectedRevision:
+		replacements[jj.ChangeIdPlaceholder] = item.ChangeId
+		replacements[jj.CommitIdPlaceholder] = item.CommitId
+		commitId, id = item.CommitId, item.ChangeId
+	case appContext.SelectedOperation:
+		replacements[jj.OperationIdPlaceholder] = item.OperationId
+		id = item.OperationId
+	}
+	kind, views := itemViews(item)
+	if len(views) == 0 {
+		return previewRequest{}, false
+	}
+	view := views[m.views[kind]%len(views)]
+	width, height := strconv.Itoa(max(m.Width-2, 0)), strconv.Itoa(max(m.Height-2, 0))
+	replacements[WidthPlaceholder] = width
+	replacements[HeightPlaceholder] = height
+	request := previewRequest{
+		sized:    isSized(view),
+		item:     kind + "\x00" + view.Name + "\x00" + id,
+		commitId: commitId,
+	}
+	if view.Shell != "" {
+		request.shell, request.env = view.Shell, replacements
+		// the pipeline can use any of the variables so they are all part of the key, except the size
+		// of the pane which only matters when the pipeline refers to it
+		request.key = commitId + "\x00" + view.Shell
+		for _, name := range slices.Sorted(maps.Keys(replacements)) {
+			if !request.sized && (name == WidthPlaceholder || name == HeightPlaceholder) {
+				continue
+			}
+			request.key += "\x00" + name + "=" + replacements[name]
+		}
+	} else {
+		request.args = jj.TemplatedArgs(view.Command, maps.Clone(replacements))
+		request.key = commitId + "\x00" + strings.Join(request.args, "\x00")
+	}
+	if len(views) > 1 {
+		request.view = view.Name
+	}
+	return request, true
+}
+
+// isSized reports whether the command of view uses the size of the pane
+func isSized(view config.PreviewCommand) bool {
+	for _, arg := range append([]string{view.Shell}, view.Command...) {
+		for _, placeholder := range []string{WidthPlaceholder, HeightPlaceholder} {
+			name := strings.TrimPrefix(placeholder, "$")
+			if strings.Contains(arg, placeholder) || strings.Contains(arg, "${"+name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// itemViews returns the type of item and the preview commands configured for it
+func itemViews(item appContext.SelectedItem) (string, config.PreviewCommands) {
+	switch item.(type) {
+	case appContext.SelectedFile:
+		return "file", config.Current.Preview.FileCommand
+	case appContext.SelectedRevision:
+		return "revision", config.Current.Preview.RevisionCommand
+	case appContext.SelectedOperation:
+		return "operation", config.Current.Preview.OplogCommand
+	}
+	return "", nil
+}
+
+// cycleView switches the preview of the selected item type to its next view, the choice is kept for
+// the items of the same type
+func (m *Model) cycleView() tea.Cmd {
+	kind, views := itemViews(m.context.SelectedItem)
+	if len(views) < 2 {
+		return nil
+	}
+	m.views[kind] = (m.views[kind] + 1) % len(views)
+	m.tag++
+	return m.load(m.tag)
+}
+
+// run reads the whole output of the command of a request, the command is killed when the context is cancelled
+func run(ctx context.Context, runner appContext.CommandRunner, location string, request previewRequest) (string, error) {
+	if request.shell != "" {
+		return runShell(ctx, location, request.shell, request.env)
+	}
+	return runJJ(ctx, runner, request.args)
+}
+
+func runShell(ctx context.Context, location string, line string, env map[string]string) (string, error) {
+	cmd := appContext.ShellCommand(ctx, location, line, env)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	switch {
+	case ctx.Err() != nil:
+		return "", ctx.Err()
+	case err != nil && stderr.Len() > 0:
+		return "", errors.New(stderr.String())
+	case err != nil:
+		return "", err
+	}
+	return strings.Trim(string(output), "\n"), nil
+}
+
+func runJJ(ctx context.Context, runner appContext.CommandRunner, args []string) (string, error) {
+	command, err := runner.RunCommandStreaming(ctx, args)
+	if err != nil {
+		return "", err
+	}
+	// stderr is read alongside stdout, jj would block on a full stderr pipe before closing its stdout
+	var stderr bytes.Buffer
+	stderrRead := make(chan struct{})
+	go func() {
+		defer close(stderrRead)
+		if command.ErrPipe != nil {
+			_, _ = io.Copy(&stderr, command.ErrPipe)
+		}
+	}()
+	output, err := io.ReadAll(command)
+	if err == nil {
+		<-stderrRead
+	}
+	closeErr := command.Close()
+	switch {
+	case ctx.Err() != nil:
+		return "", ctx.Err()
+	case err != nil:
+		return "", err
+	case closeErr != nil && stderr.Len() > 0:
+		return "", errors.New(stderr.String())
+	case closeErr != nil:
+		return "", closeErr
+	}
+	return strings.Trim(string(output), "\n"), nil
+}
+
+func (m *Model) View() string {
+	var w strings.Builder
+	width := max(m.Width-2, 0)
+	for current := m.viewRange.start; current <= m.viewRange.end && current < len(m.rows); current++ {
+		if current > m.viewRange.start {
+			w.WriteString("\n")
 		}
+		w.WriteString(ansi.Cut(m.rows[current], m.xOffset, m.xOffset+width))
 	}
 	view := lipgloss.Place(m.Width-2, m.Height-2, 0, 0, w.String())
-	return m.borderStyle.Render(view)
+	if m.label == "" {
+		return m.borderStyle.Render(view)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, m.labelledTopBorder(), m.borderStyle.BorderTop(false).Render(view))
+}
+
+// labelledTopBorder draws the top border with the name of the active view in it
+func (m *Model) labelledTopBorder() string {
+	border := m.borderStyle.GetBorderStyle()
+	style := lipgloss.NewStyle().
+		Foreground(m.borderStyle.GetBorderTopForeground()).
+		Background(m.borderStyle.GetBorderTopBackground())
+	label := ansi.Truncate(" "+m.label+" ", max(m.Width-4, 0), "")
+	fill := max(m.Width-3-lipgloss.Width(label), 0)
+	return style.Render(border.TopLeft + border.Top + label + strings.Repeat(border.Top, fill) + border.TopRight)
 }
 
 func (m *Model) reset() {
@@ -207,17 +645,34 @@ func (m *Model) Shrink() {
 	}
 }
 
-func New(context *context.MainContext) Model {
+func newMatchStyles() (lipgloss.Style, lipgloss.Style) {
+	return common.DefaultPalette.Get("preview matched"), common.DefaultPalette.Get("preview matched selected")
+}
+
+func newBorderStyle() lipgloss.Style {
 	borderStyle := common.DefaultPalette.GetBorder("preview border", lipgloss.NormalBorder())
-	borderStyle = borderStyle.Inherit(common.DefaultPalette.Get("preview text"))
+	return borderStyle.Inherit(common.DefaultPalette.Get("preview text"))
+}
+
+func New(context *appContext.MainContext) Model {
+	borderStyle := newBorderStyle()
+	matchStyle, currentMatchStyle := newMatchStyles()
 
 	return Model{
 		Sizeable:                &common.Sizeable{Width: 0, Height: 0},
 		viewRange:               &viewRange{start: 0, end: 0},
 		context:                 context,
+		cache:                   newCache(config.Current.Preview.CacheSize),
 		keyMap:                  config.Current.GetKeyMap(),
 		help:                    help.New(),
 		borderStyle:             borderStyle,
+		matchStyle:              matchStyle,
+		currentMatchStyle:       currentMatchStyle,
+		wrap:                    config.Current.Preview.Wrap,
+		views:                   map[string]int{},
+		lines:                   []string{""},
+		rows:                    []string{""},
+		rowOf:                   []int{0},
 		previewAtBottom:         config.Current.Preview.ShowAtBottom,
 		previewVisible:          config.Current.Preview.ShowAtStart,
 		previewWindowPercentage: config.Current.Preview.WidthPercentage,
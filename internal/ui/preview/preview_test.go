@@ -0,0 +1,333 @@
+package preview
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/common"
+	appContext "github.com/idursun/jjui/internal/ui/context"
+	"github.com/idursun/jjui/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := newCache(2)
+	c.put("a", "1")
+	c.put("b", "2")
+	_, _ = c.get("a")
+	c.put("c", "3")
+
+	_, ok := c.get("b")
+	assert.False(t, ok)
+	content, ok := c.get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "1", content)
+	_, ok = c.get("c")
+	assert.True(t, ok)
+
+}
+
+func TestCache_DropsPreviewsOfChangedCommits(t *testing.T) {
+	c := newCache(10)
+	c.put("123\x00show", "1")
+	c.put("123\x00diff", "2")
+	c.put("456\x00show", "3")
+	c.put("\x00op show", "4")
+	c.dropCommits([]string{"123", ""})
+
+	_, ok := c.get("123\x00show")
+	assert.False(t, ok)
+	_, ok = c.get("123\x00diff")
+	assert.False(t, ok)
+	_, ok = c.get("456\x00show")
+	assert.True(t, ok)
+	_, ok = c.get("\x00op show")
+	assert.True(t, ok, "expected the previews without a commit to be kept")
+}
+
+func revisionPreview(changeId string) []string {
+	return jj.TemplatedArgs(config.Current.Preview.RevisionCommand[0].Command, map[string]string{jj.ChangeIdPlaceholder: changeId})
+}
+
+func TestLoad_ShowsCachedPreviewWithoutRunningTheCommand(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(revisionPreview("abc")).SetOutput([]byte("abc preview\n"))
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	ctx.SelectedItem = appContext.SelectedRevision{ChangeId: "abc", CommitId: "123"}
+	model := New(ctx)
+	model.SetHeight(10)
+
+	cmd := model.load(model.tag)
+	require.NotNil(t, cmd)
+	m, _ := model.Update(cmd())
+	assert.Equal(t, "abc preview", m.content)
+
+	m.updatePreviewContent("", "")
+	assert.Nil(t, m.load(m.tag), "expected the cached preview to be shown without a command")
+	assert.Equal(t, "abc preview", m.content)
+}
+
+func TestLoad_IgnoresStaleResults(t *testing.T) {
+	ctx := test.NewTestContext(test.NewTestCommandRunner(t))
+	model := New(ctx)
+	model.tag = 2
+
+	m, _ := model.Update(previewLoadedMsg{tag: 1, key: "old", content: "old preview"})
+	assert.Empty(t, m.content)
+	_, cached := m.cache.get("old")
+	assert.True(t, cached, "expected a finished preview to be cached even if the selection moved on")
+
+	m, _ = m.Update(previewLoadedMsg{tag: 2, key: "cancelled", err: context.Canceled})
+	assert.Empty(t, m.content)
+}
+
+func TestCommitsChanged_ReloadsOnlyTheChangedCommits(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(revisionPreview("abc")).SetOutput([]byte("abc preview"))
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	ctx.SelectedItem = appContext.SelectedRevision{ChangeId: "abc", CommitId: "123"}
+	model := New(ctx)
+	model.SetHeight(10)
+
+	m, _ := model.Update(model.load(model.tag)())
+	_, cmd := m.Update(common.CommitsChangedMsg{CommitIds: []string{"456"}})
+	assert.Nil(t, cmd, "expected the preview of an unchanged commit to be kept")
+	assert.Nil(t, m.load(m.tag))
+
+	_, cmd = m.Update(common.CommitsChangedMsg{CommitIds: []string{"123"}})
+	assert.NotNil(t, cmd)
+	assert.NotNil(t, m.load(m.tag), "expected the preview of a changed commit to be loaded again")
+}
+
+// blockingRunner runs commands that write all of their stderr before their stdout
+type blockingRunner struct {
+	*test.CommandRunner
+	stderr []byte
+}
+
+func (r blockingRunner) RunCommandStreaming(context.Context, []string) (*appContext.StreamingCommand, error) {
+	stdout, stdoutWriter := io.Pipe()
+	stderr, stderrWriter := io.Pipe()
+	go func() {
+		_, _ = stderrWriter.Write(r.stderr)
+		_ = stderrWriter.Close()
+		_, _ = stdoutWriter.Write([]byte("output\n"))
+		_ = stdoutWriter.Close()
+	}()
+	return &appContext.StreamingCommand{ReadCloser: stdout, ErrPipe: stderr}, nil
+}
+
+func TestRunJJ_ReadsStderrWhileReadingStdout(t *testing.T) {
+	runner := blockingRunner{CommandRunner: test.NewTestCommandRunner(t), stderr: bytes.Repeat([]byte("warning\n"), 1<<17)}
+	done := make(chan string)
+	go func() {
+		output, _ := runJJ(context.Background(), runner, []string{"show"})
+		done <- output
+	}()
+	select {
+	case output := <-done:
+		assert.Equal(t, "output", output)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a command writing a lot to stderr not to block")
+	}
+}
+
+func TestPrefetch_LoadsAdjacentItems(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(revisionPreview("def")).SetOutput([]byte("def preview"))
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	ctx.AdjacentItems = []appContext.SelectedItem{appContext.SelectedRevision{ChangeId: "def", CommitId: "456"}}
+	model := New(ctx)
+
+	cmd := model.prefetch()
+	require.NotNil(t, cmd)
+	m, _ := model.Update(cmd())
+	request, _ := m.request(ctx.AdjacentItems[0])
+	content, ok := m.cache.get(request.key)
+	assert.True(t, ok)
+	assert.Equal(t, "def preview", content)
+	assert.Nil(t, m.prefetch(), "expected nothing to prefetch once the adjacent previews are cached")
+}
+
+func numberedLines(count int, line func(i int) string) string {
+	var lines []string
+	for i := range count {
+		lines = append(lines, line(i))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestSearch_JumpsBetweenMatches(t *testing.T) {
+	model := New(test.NewTestContext(test.NewTestCommandRunner(t)))
+	model.SetWidth(40)
+	model.SetHeight(7)
+	model.updatePreviewContent("", numberedLines(60, func(i int) string {
+		if i%20 == 10 {
+			return fmt.Sprintf("line %d \x1b[31mNeedle\x1b[0m", i)
+		}
+		return fmt.Sprintf("line %d", i)
+	}))
+
+	m, _ := model.Update(PreviewCmd(searchMsg("needle"))())
+	require.Len(t, m.matches, 3)
+	assert.Equal(t, match{line: 10, start: 8, end: 14, text: "Needle"}, m.matches[0])
+	assert.Contains(t, ansi.Strip(m.View()), "line 10 Needle")
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n"), Alt: true})
+	assert.Equal(t, 1, m.current)
+	assert.Contains(t, ansi.Strip(m.View()), "line 30 Needle")
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p"), Alt: true})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p"), Alt: true})
+	assert.Equal(t, 2, m.current, "expected the previous match of the first one to be the last one")
+	assert.Contains(t, ansi.Strip(m.View()), "line 50 Needle")
+}
+
+func TestSearch_ScrollsHorizontallyToTheMatch(t *testing.T) {
+	model := New(test.NewTestContext(test.NewTestCommandRunner(t)))
+	model.SetWidth(22)
+	model.SetHeight(5)
+	model.updatePreviewContent("", strings.Repeat("x", 100)+"needle")
+
+	m, _ := model.Update(searchMsg("needle"))
+	assert.Contains(t, m.View(), "needle")
+	assert.Greater(t, m.xOffset, 0)
+}
+
+func TestToggleWrap(t *testing.T) {
+	model := New(test.NewTestContext(test.NewTestCommandRunner(t)))
+	model.SetWidth(12)
+	model.SetHeight(10)
+	model.updatePreviewContent("", "aaaa bbbb cccc dddd\nshort")
+	assert.Equal(t, 2, model.contentLineCount)
+	assert.NotContains(t, model.View(), "cccc")
+
+	m, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l"), Alt: true})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l"), Alt: true})
+	assert.Contains(t, m.View(), "dddd", "expected long lines to scroll horizontally")
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w"), Alt: true})
+	assert.Equal(t, 0, m.xOffset)
+	assert.Equal(t, 3, m.contentLineCount)
+	assert.Contains(t, m.View(), "cccc dddd")
+}
+
+func TestRefresh_KeepsScrollPositionOfTheSameItem(t *testing.T) {
+	model := New(test.NewTestContext(test.NewTestCommandRunner(t)))
+	model.SetWidth(20)
+	model.SetHeight(7)
+	content := numberedLines(30, func(i int) string { return fmt.Sprintf("line %d", i) })
+	model.updatePreviewContent("revision\x00abc", content)
+
+	m, _ := model.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	start := m.viewRange.start
+	require.Greater(t, start, 0)
+
+	m, _ = m.Update(previewLoadedMsg{tag: m.tag, key: "new commit", item: "revision\x00abc", content: content})
+	assert.Equal(t, start, m.viewRange.start)
+
+	m, _ = m.Update(previewLoadedMsg{tag: m.tag, key: "other", item: "revision\x00def", content: content})
+	assert.Equal(t, 0, m.viewRange.start)
+}
+
+func TestCycleView_RemembersTheViewPerItemType(t *testing.T) {
+	views := config.Current.Preview.RevisionCommand
+	defer func() { config.Current.Preview.RevisionCommand = views }()
+	config.Current.Preview.RevisionCommand = config.PreviewCommands{
+		{Name: "show", Command: []string{"show", "-r", "$change_id"}},
+		{Name: "stat", Command: []string{"show", "--stat", "-r", "$change_id"}},
+	}
+
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect([]string{"show", "--stat", "-r", "abc"}).SetOutput([]byte("abc stat"))
+	commandRunner.Expect([]string{"show", "--stat", "-r", "def"}).SetOutput([]byte("def stat"))
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	ctx.SelectedItem = appContext.SelectedRevision{ChangeId: "abc", CommitId: "123"}
+	model := New(ctx)
+	model.SetWidth(30)
+	model.SetHeight(5)
+
+	m, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v"), Alt: true})
+	require.NotNil(t, cmd)
+	m, _ = m.Update(cmd())
+	assert.Equal(t, "abc stat", m.content)
+	assert.Contains(t, m.View(), "┌─ stat ─")
+
+	ctx.SelectedItem = appContext.SelectedRevision{ChangeId: "def", CommitId: "456"}
+	m, _ = m.Update(m.load(m.tag)())
+	assert.Equal(t, "def stat", m.content)
+
+	ctx.SelectedItem = appContext.SelectedOperation{OperationId: "op"}
+	request, _ := m.request(ctx.SelectedItem)
+	assert.Equal(t, "operation\x00"+config.Current.Preview.OplogCommand[0].Name+"\x00op", request.item, "expected other item types to keep their own view")
+}
+
+func TestShellCommand_RerunsWhenResized(t *testing.T) {
+	views := config.Current.Preview.RevisionCommand
+	defer func() { config.Current.Preview.RevisionCommand = views }()
+	config.Current.Preview.RevisionCommand = config.PreviewCommands{
+		{Name: "size", Shell: `printf '\033[31m%s\033[0m %sx%s' "$change_id" "$width" "$height" | cat`},
+	}
+
+	ctx := test.NewTestContext(test.NewTestCommandRunner(t))
+	ctx.Location = t.TempDir()
+	ctx.SelectedItem = appContext.SelectedRevision{ChangeId: "abc", CommitId: "123"}
+	model := New(ctx)
+	model.SetWidth(30)
+	model.SetHeight(12)
+
+	m, _ := model.Update(model.load(model.tag)())
+	assert.Equal(t, "\x1b[31mabc\x1b[0m 28x10", m.content)
+
+	m.SetWidth(40)
+	m, cmd := m.Update(Resized())
+	require.NotNil(t, cmd, "expected a sized preview to reload")
+	m, _ = m.Update(m.load(m.tag)())
+	assert.Equal(t, "\x1b[31mabc\x1b[0m 38x10", m.content)
+
+	config.Current.Preview.RevisionCommand = views
+	_, cmd = m.Update(Resized())
+	assert.Nil(t, cmd, "expected a preview that does not use the size to be kept")
+}
+
+func TestShellCommand_CachesEachFile(t *testing.T) {
+	views := config.Current.Preview.FileCommand
+	defer func() { config.Current.Preview.FileCommand = views }()
+	config.Current.Preview.FileCommand = config.PreviewCommands{
+		{Name: "file", Shell: `printf '%s' "$file"`},
+	}
+
+	ctx := test.NewTestContext(test.NewTestCommandRunner(t))
+	ctx.Location = t.TempDir()
+	ctx.SelectedItem = appContext.SelectedFile{ChangeId: "abc", CommitId: "123", File: "a.txt"}
+	model := New(ctx)
+	model.SetWidth(30)
+	model.SetHeight(12)
+
+	m, _ := model.Update(model.load(model.tag)())
+	assert.Equal(t, "a.txt", m.content)
+
+	ctx.SelectedItem = appContext.SelectedFile{ChangeId: "abc", CommitId: "123", File: "b.txt"}
+	cmd := m.load(m.tag)
+	require.NotNil(t, cmd, "expected the preview of another file not to come from the cache")
+	m, _ = m.Update(cmd())
+	assert.Equal(t, "b.txt", m.content)
+}
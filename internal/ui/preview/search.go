@@ -0,0 +1,68 @@
+package preview
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// match is an occurrence of the search term, start and end are the cells it covers in its line
+type match struct {
+	line  int
+	start int
+	end   int
+	text  string
+}
+
+// searchMsg starts a new search in the preview, an empty term clears the search
+type searchMsg string
+
+// findMatches returns the case-insensitive occurrences of term in lines, ordered by position
+func findMatches(lines []string, term string) []match {
+	if term == "" {
+		return nil
+	}
+	var matches []match
+	term = strings.ToLower(term)
+	for i, line := range lines {
+		plain := ansi.Strip(line)
+		lower := strings.ToLower(plain)
+		if len(lower) != len(plain) {
+			// lowering changed the byte offsets, fall back to matching the text as it is
+			lower = plain
+		}
+		for at := 0; ; {
+			index := strings.Index(lower[at:], term)
+			if index == -1 {
+				break
+			}
+			index += at
+			text := plain[index : index+len(term)]
+			start := ansi.StringWidth(plain[:index])
+			matches = append(matches, match{line: i, start: start, end: start + ansi.StringWidth(text), text: text})
+			at = index + len(term)
+		}
+	}
+	return matches
+}
+
+// highlight renders the matches of a line with style, and the current match with currentStyle
+func highlight(line string, matches []match, current int, style lipgloss.Style, currentStyle lipgloss.Style) string {
+	if len(matches) == 0 {
+		return line
+	}
+	var w strings.Builder
+	at := 0
+	for i, match := range matches {
+		w.WriteString(ansi.Cut(line, at, match.start))
+		if i == current {
+			w.WriteString(currentStyle.Render(match.text))
+		} else {
+			w.WriteString(style.Render(match.text))
+		}
+		at = match.end
+	}
+	w.WriteString(ansi.TruncateLeft(line, at, ""))
+	return w.String()
+}
@@ -6,8 +6,10 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/idursun/jjui/internal/jj"
 	"github.com/idursun/jjui/internal/parser"
 	"github.com/idursun/jjui/internal/screen"
+	"github.com/idursun/jjui/internal/ui/common"
 	"github.com/idursun/jjui/internal/ui/common/list"
 	"github.com/idursun/jjui/internal/ui/operations"
 )
@@ -32,6 +34,7 @@ type itemRenderer struct {
 	SearchText       string
 	AceJumpPrefix    *string
 	isChecked        bool
+	signature        jj.SignatureStatus
 }
 
 func (ir itemRenderer) writeSection(w io.Writer, current parser.GraphGutter, extended parser.GraphGutter, highlight bool, section string, width int) {
@@ -103,7 +106,7 @@ func (ir itemRenderer) Render(w io.Writer, width int) {
 		for i, segment := range segmentedLine.Gutter.Segments {
 			gutterInLane := ir.isGutterInLane(lineIndex, i)
 			text := ir.updateGutterText(lineIndex, i, segment.Text)
-			style := segment.Style
+			style := *segment.Style
 			if gutterInLane {
 				style = style.Inherit(ir.textStyle)
 			} else {
@@ -128,7 +131,7 @@ func (ir itemRenderer) Render(w io.Writer, width int) {
 				fmt.Fprint(&lw, ir.beforeCommitId)
 			}
 
-			style := segment.Style
+			style := *segment.Style
 			if isHighlighted {
 				style = style.Inherit(ir.selectedStyle)
 			} else if inLane {
@@ -150,6 +153,15 @@ func (ir itemRenderer) Render(w io.Writer, width int) {
 			fmt.Fprint(&lw, style.Render(segment.Text))
 		}
 
+		// render: signature status
+		if segmentedLine.Flags&parser.Revision == parser.Revision && ir.signature != jj.SignatureNone {
+			style := signatureStyle(ir.signature)
+			if isHighlighted {
+				style = style.Background(ir.selectedStyle.GetBackground())
+			}
+			fmt.Fprint(&lw, style.Render(fmt.Sprintf(" [%s signature]", ir.signature)))
+		}
+
 		// render: affected by last operation
 		if segmentedLine.Flags&parser.Revision == parser.Revision && row.IsAffected {
 			style := ir.dimmedStyle
@@ -185,7 +197,7 @@ func (ir itemRenderer) Render(w io.Writer, width int) {
 		for i, segment := range segmentedLine.Gutter.Segments {
 			gutterInLane := ir.isGutterInLane(lineIndex, i)
 			text := ir.updateGutterText(lineIndex, i, segment.Text)
-			style := segment.Style
+			style := *segment.Style
 			if gutterInLane {
 				style = style.Inherit(ir.textStyle)
 			} else {
@@ -223,3 +235,14 @@ func (ir itemRenderer) aceJumpIndex(segment *screen.Segment, row parser.Row) int
 	}
 	return idx
 }
+
+func signatureStyle(status jj.SignatureStatus) lipgloss.Style {
+	switch status {
+	case jj.SignatureGood:
+		return common.DefaultPalette.Get("revisions signature good")
+	case jj.SignatureBad, jj.SignatureInvalid:
+		return common.DefaultPalette.Get("revisions signature bad")
+	default:
+		return common.DefaultPalette.Get("revisions signature unknown")
+	}
+}
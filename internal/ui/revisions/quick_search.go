@@ -0,0 +1,116 @@
+package revisions
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/parser"
+	"github.com/idursun/jjui/internal/ui/common"
+)
+
+// streamingSearch is a quick search which is waiting for more rows because the loaded ones have no match.
+// The rows from `from` onwards have not been searched yet, the ones before `origin` are searched last.
+type streamingSearch struct {
+	origin int
+	from   int
+}
+
+// newMatcher returns the function that tells whether a line of a revision matches the search text
+func newMatcher(text string, searchConfig config.QuickSearchConfig) (func(string) bool, error) {
+	if searchConfig.Regex {
+		if searchConfig.IgnoreCase {
+			text = "(?i)" + text
+		}
+		re, err := regexp.Compile(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quick search pattern: %w", err)
+		}
+		return re.MatchString, nil
+	}
+	if searchConfig.IgnoreCase {
+		text = strings.ToLower(text)
+		return func(line string) bool {
+			return strings.Contains(strings.ToLower(line), text)
+		}, nil
+	}
+	return func(line string) bool {
+		return strings.Contains(line, text)
+	}, nil
+}
+
+func rowMatches(row *parser.Row, matches func(string) bool) bool {
+	for _, line := range row.Lines {
+		var text strings.Builder
+		for _, segment := range line.Segments {
+			text.WriteString(segment.Text)
+		}
+		if text.Len() > 0 && matches(text.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+// startSearch moves the cursor to the first match at or after start, or keeps streaming rows until
+// there is one
+func (m *Model) startSearch(start int) tea.Cmd {
+	m.searching = nil
+	if m.matcher == nil || len(m.rows) == 0 {
+		return nil
+	}
+	start = min(start, len(m.rows))
+	if index := m.searchRange(start, len(m.rows)); index != -1 {
+		m.cursor = index
+		return m.updateSelection()
+	}
+	if m.hasMore {
+		m.searching = &streamingSearch{origin: start, from: len(m.rows)}
+		return m.requestMoreRows(m.tag.Load())
+	}
+	return m.wrapSearch(start)
+}
+
+// continueSearch searches the rows that have been streamed since the last batch
+func (m *Model) continueSearch() tea.Cmd {
+	search := m.searching
+	if index := m.searchRange(search.from, len(m.rows)); index != -1 {
+		m.searching = nil
+		m.cursor = index
+		return m.updateSelection()
+	}
+	search.from = len(m.rows)
+	if m.hasMore {
+		return m.requestMoreRows(m.tag.Load())
+	}
+	m.searching = nil
+	return m.wrapSearch(search.origin)
+}
+
+// wrapSearch searches the rows before start once all the rows after it are searched
+func (m *Model) wrapSearch(start int) tea.Cmd {
+	if index := m.searchRange(0, start); index != -1 {
+		m.cursor = index
+		return m.updateSelection()
+	}
+	text := m.quickSearch
+	return func() tea.Msg {
+		return common.CommandCompletedMsg{Err: fmt.Errorf("no revision matches %q", text)}
+	}
+}
+
+func (m *Model) searchRange(from int, to int) int {
+	for i := from; i < to; i++ {
+		if rowMatches(&m.rows[i], m.matcher) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m *Model) searchProgressView() string {
+	return m.dimmedStyle.Render(fmt.Sprintf("searching for %q… %d revisions searched, press %s to cancel",
+		m.quickSearch, m.searching.from, m.keymap.Cancel.Help().Key))
+}
@@ -0,0 +1,85 @@
+package revisions
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/parser"
+	"github.com/idursun/jjui/internal/screen"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSearchRow(changeId string, description string) parser.Row {
+	return parser.Row{
+		Commit: &jj.Commit{ChangeId: changeId, CommitId: changeId},
+		Lines: []*parser.GraphRowLine{
+			{Segments: []*screen.Segment{{Text: changeId + " "}, {Text: description}}},
+		},
+	}
+}
+
+func TestNewMatcher(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		config  config.QuickSearchConfig
+		line    string
+		matches bool
+	}{
+		{"case sensitive", "Fix", config.QuickSearchConfig{}, "fix the bug", false},
+		{"ignore case", "Fix", config.QuickSearchConfig{IgnoreCase: true}, "fix the bug", true},
+		{"literal", "a.c", config.QuickSearchConfig{}, "abc", false},
+		{"regex", "a.c", config.QuickSearchConfig{Regex: true}, "abc", true},
+		{"regex ignoring case", "^FIX", config.QuickSearchConfig{Regex: true, IgnoreCase: true}, "fix the bug", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := newMatcher(tt.text, tt.config)
+			require.NoError(t, err)
+			assert.Equal(t, tt.matches, matches(tt.line))
+		})
+	}
+}
+
+func TestNewMatcher_InvalidRegex(t *testing.T) {
+	_, err := newMatcher("fix(", config.QuickSearchConfig{Regex: true})
+	assert.ErrorContains(t, err, "invalid quick search pattern")
+}
+
+func TestQuickSearch_StreamsUntilMatch(t *testing.T) {
+	model := New(test.NewTestContext(test.NewTestCommandRunner(t)))
+	model.SetHeight(10)
+	model.rows = []parser.Row{newSearchRow("aaa", "first"), newSearchRow("bbb", "second")}
+	model.offScreenRows = model.rows
+	model.hasMore = true
+
+	model.Update(common.QuickSearchMsg("wanted"))
+	require.NotNil(t, model.searching)
+	assert.Equal(t, 0, model.cursor)
+
+	model.Update(appendRowsBatchMsg{rows: []parser.Row{newSearchRow("ccc", "third")}, hasMore: true, tag: model.tag.Load()})
+	require.NotNil(t, model.searching, "expected the search to keep streaming")
+	assert.Equal(t, 3, model.searching.from)
+
+	model.Update(appendRowsBatchMsg{rows: []parser.Row{newSearchRow("ddd", "the wanted one")}, hasMore: true, tag: model.tag.Load()})
+	assert.Nil(t, model.searching)
+	assert.Equal(t, 3, model.cursor)
+}
+
+func TestQuickSearch_CancelStopsStreaming(t *testing.T) {
+	model := New(test.NewTestContext(test.NewTestCommandRunner(t)))
+	model.SetHeight(10)
+	model.rows = []parser.Row{newSearchRow("aaa", "first")}
+	model.offScreenRows = model.rows
+	model.hasMore = true
+
+	model.Update(common.QuickSearchMsg("wanted"))
+	require.NotNil(t, model.searching)
+	model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Nil(t, model.searching)
+}
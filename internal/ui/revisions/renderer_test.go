@@ -0,0 +1,94 @@
+package revisions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/parser"
+	appContext "github.com/idursun/jjui/internal/ui/context"
+	"github.com/idursun/jjui/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSyntheticModel(b testing.TB, commits int) *Model {
+	controlChannel := make(chan parser.ControlMsg)
+	receiver, err := parser.ParseRowsStreaming(strings.NewReader(test.SyntheticLog(commits)), controlChannel, commits)
+	if err != nil {
+		b.Fatal(err)
+	}
+	controlChannel <- parser.RequestMore
+	batch := <-receiver
+	controlChannel <- parser.Close
+
+	model := New(&appContext.MainContext{})
+	model.SetWidth(120)
+	model.SetHeight(40)
+	model.rows = batch.Rows
+	model.offScreenRows = batch.Rows
+	return model
+}
+
+func TestView_JumpToEnd(t *testing.T) {
+	model := newSyntheticModel(t, 1000)
+	model.View()
+	model.cursor = 999
+	view := model.View()
+	assert.Contains(t, view, "commit 999")
+	assert.Greater(t, model.renderer.FirstRowIndex, 950, "expected the rows above the view not to be rendered")
+}
+
+func benchmarkView(b *testing.B, cursor int, tracer bool) {
+	model := newSyntheticModel(b, 100_000)
+	previous := config.Current.UI.Tracer.Enabled
+	config.Current.UI.Tracer.Enabled = tracer
+	b.Cleanup(func() { config.Current.UI.Tracer.Enabled = previous })
+	model.cursor = cursor
+	b.ReportAllocs()
+	for b.Loop() {
+		model.View()
+	}
+}
+
+func BenchmarkView_Top(b *testing.B) {
+	benchmarkView(b, 10, false)
+}
+
+func BenchmarkView_Bottom(b *testing.B) {
+	benchmarkView(b, 99_990, false)
+}
+
+func BenchmarkView_BottomWithTracer(b *testing.B) {
+	benchmarkView(b, 99_990, true)
+}
+
+func TestView_SignatureBadge(t *testing.T) {
+	previous := config.Current.Revisions.ShowSignatures
+	config.Current.Revisions.ShowSignatures = true
+	t.Cleanup(func() { config.Current.Revisions.ShowSignatures = previous })
+
+	commandRunner := test.NewTestCommandRunner(t)
+	model := newSyntheticModel(t, 2)
+	model.context = test.NewTestContext(commandRunner)
+
+	// the log shows shortened commit ids while the signatures are listed with the full ones
+	var commitIds []string
+	var output strings.Builder
+	for _, row := range model.rows {
+		if row.Commit.IsRoot() {
+			continue
+		}
+		commitIds = append(commitIds, row.Commit.CommitId)
+		fmt.Fprintf(&output, "%s0123456789 good\n", row.Commit.CommitId)
+	}
+	commandRunner.Expect(jj.GetSignatures(strings.Join(commitIds, "|"))).SetOutput([]byte(output.String()))
+	defer commandRunner.Verify()
+
+	cmd := model.loadSignatures()
+	assert.NotNil(t, cmd)
+	model.Update(cmd())
+	assert.Nil(t, model.loadSignatures(), "expected the known signatures not to be queried again")
+	assert.Contains(t, model.View(), "[good signature]")
+}
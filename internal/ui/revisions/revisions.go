@@ -14,6 +14,7 @@ import (
 	"github.com/idursun/jjui/internal/ui/operations/duplicate"
 	"github.com/idursun/jjui/internal/ui/operations/revert"
 	"github.com/idursun/jjui/internal/ui/operations/set_parents"
+	"github.com/idursun/jjui/internal/ui/operations/sign"
 
 	"github.com/idursun/jjui/internal/parser"
 	"github.com/idursun/jjui/internal/ui/operations/describe"
@@ -33,6 +34,8 @@ import (
 	"github.com/idursun/jjui/internal/ui/operations/copy"
 	"github.com/idursun/jjui/internal/ui/operations/details"
 	"github.com/idursun/jjui/internal/ui/operations/evolog"
+	"github.com/idursun/jjui/internal/ui/operations/metadata"
+	"github.com/idursun/jjui/internal/ui/operations/new_change"
 	"github.com/idursun/jjui/internal/ui/operations/rebase"
 	"github.com/idursun/jjui/internal/ui/operations/squash"
 )
@@ -57,12 +60,19 @@ type Model struct {
 	output           string
 	err              error
 	quickSearch      string
+	matcher          func(string) bool
+	searching        *streamingSearch
 	previousOpLogId  string
 	isLoading        bool
 	renderer         *revisionListRenderer
 	textStyle        lipgloss.Style
 	dimmedStyle      lipgloss.Style
 	selectedStyle    lipgloss.Style
+	signatures       map[string]jj.SignatureStatus
+	// rowTexts are the texts of the rows by their commit ids as they were last loaded
+	rowTexts map[string]string
+	// workingCopyChanged is set by a refresh until the working copy is reported by commitsChanged
+	workingCopyChanged bool
 }
 
 func (m *Model) Cursor() int {
@@ -79,6 +89,11 @@ func (m *Model) Len() int {
 	return len(m.rows)
 }
 
+// ItemHeight is the height of the row without its operation sections, the same as the height of its renderer
+func (m *Model) ItemHeight(index int) int {
+	return len(m.rows[index].Lines)
+}
+
 func (m *Model) GetItemRenderer(index int) list.IItemRenderer {
 	var (
 		before, after, renderOverDescription, beforeCommitId, beforeChangeId string
@@ -111,6 +126,7 @@ func (m *Model) GetItemRenderer(index int) list.IItemRenderer {
 		dimmedStyle:    m.dimmedStyle,
 		selectedStyle:  m.selectedStyle,
 		isChecked:      m.renderer.selections[row.Commit.GetChangeId()],
+		signature:      m.signatures[row.Commit.CommitId],
 		isGutterInLane: func(lineIndex, segmentIndex int) bool {
 			return m.renderer.tracer.IsGutterInLane(index, lineIndex, segmentIndex)
 		},
@@ -149,6 +165,21 @@ type appendRowsBatchMsg struct {
 	tag     uint64
 }
 
+type updateSignaturesMsg struct {
+	signatures map[string]jj.SignatureStatus
+}
+
+// jumpToRevisionMsg carries the revision a jumpTo query has found
+type jumpToRevisionMsg struct {
+	from     int
+	tag      uint64
+	revision string
+	orNext   bool
+}
+
+// operationIdMsg carries the id of the latest operation, it is checked by auto refresh
+type operationIdMsg string
+
 func (m *Model) IsEditing() bool {
 	if f, ok := m.op.(common.Editable); ok {
 		return f.IsEditing()
@@ -235,31 +266,57 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 func (m *Model) internalUpdate(msg tea.Msg) (*Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case common.CloseViewMsg:
+		m.clearCheckedFiles()
 		m.op = operations.NewDefault()
 		return m, m.updateSelection()
 	case common.QuickSearchMsg:
 		m.quickSearch = string(msg)
-		m.cursor = m.search(0)
 		m.op = operations.NewDefault()
 		m.renderer.Reset()
-		return m, nil
+		m.matcher = nil
+		if m.quickSearch == "" {
+			return m, nil
+		}
+		matcher, err := newMatcher(m.quickSearch, config.Current.Revisions.QuickSearch)
+		if err != nil {
+			return m, func() tea.Msg {
+				return common.CommandCompletedMsg{Err: err}
+			}
+		}
+		m.matcher = matcher
+		return m, m.startSearch(0)
 	case common.CommandCompletedMsg:
 		m.output = msg.Output
 		m.err = msg.Err
 		return m, nil
 	case common.AutoRefreshMsg:
-		id, _ := m.context.RunCommandImmediate(jj.OpLogId(true))
-		currentOperationId := string(id)
+		return m, m.context.Query(jj.OpLogId(true), func(output []byte, _ error) tea.Msg {
+			return operationIdMsg(output)
+		})
+	case operationIdMsg:
+		currentOperationId := string(msg)
 		log.Println("Previous operation ID:", m.previousOpLogId, "Current operation ID:", currentOperationId)
 		if currentOperationId != m.previousOpLogId {
 			m.previousOpLogId = currentOperationId
 			return m, common.RefreshAndKeepSelections
 		}
+	case jumpToRevisionMsg:
+		if msg.from != m.cursor || msg.tag != m.tag.Load() {
+			// the cursor has moved or the rows were reloaded while the query was running
+			return m, nil
+		}
+		if index := m.selectRevision(msg.revision); index != -1 {
+			m.cursor = index
+		} else if msg.orNext && m.cursor < len(m.rows)-1 {
+			m.cursor++
+		}
+		return m, m.updateSelection()
 	case common.RefreshMsg:
 		if !msg.KeepSelections {
 			m.context.ClearCheckedItems(reflect.TypeFor[appContext.SelectedRevision]())
 		}
 		m.isLoading = true
+		m.workingCopyChanged = true
 		var cmd tea.Cmd
 		m.op, cmd = m.op.Update(msg)
 		if config.Current.Revisions.LogBatching {
@@ -271,7 +328,7 @@ func (m *Model) internalUpdate(msg tea.Msg) (*Model, tea.Cmd) {
 	case updateRevisionsMsg:
 		m.isLoading = false
 		m.updateGraphRows(msg.rows, msg.selectedRevision)
-		return m, tea.Batch(m.highlightChanges, m.updateSelection(), func() tea.Msg {
+		return m, tea.Batch(m.highlightChanges, m.loadSignatures(), m.commitsChanged(), m.updateSelection(), func() tea.Msg {
 			return common.UpdateRevisionsSuccessMsg{}
 		})
 	case startRowsStreamingMsg:
@@ -321,7 +378,10 @@ func (m *Model) internalUpdate(msg tea.Msg) (*Model, tea.Cmd) {
 			m.cursor = 0
 		}
 
-		cmds := []tea.Cmd{m.highlightChanges, m.updateSelection()}
+		cmds := []tea.Cmd{m.highlightChanges, m.loadSignatures(), m.commitsChanged(), m.updateSelection()}
+		if m.searching != nil {
+			cmds = append(cmds, m.continueSearch())
+		}
 		if !m.hasMore {
 			cmds = append(cmds, func() tea.Msg {
 				return common.UpdateRevisionsSuccessMsg{}
@@ -329,54 +389,151 @@ func (m *Model) internalUpdate(msg tea.Msg) (*Model, tea.Cmd) {
 		}
 		return m, tea.Batch(cmds...)
 	case common.StartSquashOperationMsg:
+		m.clearCheckedFiles()
 		return m.startSquash(jj.NewSelectedRevisions(msg.Revision), msg.Files)
+	case common.StartCommitOperationMsg:
+		m.clearCheckedFiles()
+		return m.startCommit(msg.Files)
+	case common.ConfigReloadedMsg:
+		m.keymap = config.Current.GetKeyMap()
+		m.textStyle = common.DefaultPalette.Get("revisions text")
+		m.dimmedStyle = common.DefaultPalette.Get("revisions dimmed")
+		m.selectedStyle = common.DefaultPalette.Get("revisions selected")
+		if m.InNormalMode() {
+			m.op = operations.NewDefault()
+		}
+		m.renderer.Reset()
+		return m, nil
+	case updateSignaturesMsg:
+		for commitId, status := range msg.signatures {
+			m.signatures[commitId] = status
+		}
+		m.renderer.Reset()
+		return m, nil
 	}
 
 	if len(m.rows) == 0 {
 		return m, nil
 	}
 
+	if action, ok := msg.(common.ActionMsg); ok {
+		return m.handleAction(action)
+	}
+
 	if op, ok := m.op.(common.Editable); ok && op.IsEditing() {
 		var cmd tea.Cmd
 		m.op, cmd = m.op.Update(msg)
 		return m, cmd
 	}
 
+	return m.handleKey(msg)
+}
+
+// handleAction starts the operation an action belongs to when it is not running yet, e.g. rebase
+// for `rebase.onto`, and offers the action to the operation having the focus. The actions the
+// operation does not match are handled like their keys even while it has the focus.
+func (m *Model) handleAction(msg common.ActionMsg) (*Model, tea.Cmd) {
+	var startCmd tea.Cmd
+	if group := msg.Group(); operationGroups[group] != "" && operationGroup(m.op) != group {
+		m, startCmd = m.handleKey(common.ActionMsg{Name: operationGroups[group]})
+		if operationGroup(m.op) != group {
+			return m, startCmd
+		}
+	}
+	if hasFocus(m.op) {
+		op, cmd, matched := common.Offer(m.op, msg)
+		m.op = op
+		if matched {
+			return m, tea.Batch(startCmd, cmd)
+		}
+	}
+	m, cmd := m.handleKey(msg)
+	return m, tea.Batch(startCmd, cmd)
+}
+
+// operationGroups are the key mapping groups of the operations started by the revisions view and the
+// actions starting them
+var operationGroups = map[string]string{
+	"rebase":          "revisions.rebase",
+	"revert":          "revisions.revert",
+	"duplicate":       "revisions.duplicate",
+	"squash":          "revisions.squash",
+	"new_placement":   "revisions.new",
+	"details":         "revisions.details",
+	"evolog":          "revisions.evolog",
+	"inline_describe": "revisions.inline_describe",
+	"copy":            "revisions.copy",
+	"metadata":        "revisions.metadata",
+}
+
+func operationGroup(op tea.Model) string {
+	switch op.(type) {
+	case *rebase.Operation:
+		return "rebase"
+	case *revert.Operation:
+		return "revert"
+	case *duplicate.Operation:
+		return "duplicate"
+	case *squash.Operation:
+		return "squash"
+	case *new_change.Operation:
+		return "new_placement"
+	case *details.Operation:
+		return "details"
+	case *evolog.Operation:
+		return "evolog"
+	case describe.Operation:
+		return "inline_describe"
+	case *copy.Operation:
+		return "copy"
+	case *metadata.Operation:
+		return "metadata"
+	}
+	return ""
+}
+
+func hasFocus(op tea.Model) bool {
+	if focusable, ok := op.(common.Focusable); ok && focusable.IsFocused() {
+		return true
+	}
+	editable, ok := op.(common.Editable)
+	return ok && editable.IsEditing()
+}
+
+func (m *Model) handleKey(msg tea.Msg) (*Model, tea.Cmd) {
 	var cmd tea.Cmd
 	switch msg := msg.(type) {
-	case tea.KeyMsg:
+	case tea.KeyMsg, common.ActionMsg:
 		switch {
-		case key.Matches(msg, m.keymap.Up):
+		case m.searching != nil && common.Matches(msg, "revisions.cancel", m.keymap.Cancel):
+			// the rows streamed so far are kept
+			m.searching = nil
+			return m, nil
+		case common.Matches(msg, "revisions.up", m.keymap.Up):
 			if m.cursor > 0 {
 				m.cursor--
 			}
 			return m, m.updateSelection()
-		case key.Matches(msg, m.keymap.Down):
+		case common.Matches(msg, "revisions.down", m.keymap.Down):
 			if m.cursor < len(m.rows)-1 {
 				m.cursor++
 			} else if m.hasMore {
 				return m, m.requestMoreRows(m.tag.Load())
 			}
 			return m, m.updateSelection()
-		case key.Matches(msg, m.keymap.JumpToParent):
-			m.jumpToParent(m.SelectedRevisions())
-			return m, m.updateSelection()
-		case key.Matches(msg, m.keymap.JumpToChildren):
-			immediate, _ := m.context.RunCommandImmediate(jj.GetFirstChild(m.SelectedRevision()))
-			index := m.selectRevision(string(immediate))
-			if index != -1 {
-				m.cursor = index
-			}
-			return m, m.updateSelection()
-		case key.Matches(msg, m.keymap.JumpToWorkingCopy):
+		case common.Matches(msg, "revisions.jump_to_parent", m.keymap.JumpToParent):
+			return m, m.jumpTo(jj.GetParent(m.SelectedRevisions()), false)
+		case common.Matches(msg, "revisions.jump_to_children", m.keymap.JumpToChildren):
+			return m, m.jumpTo(jj.GetFirstChild(m.SelectedRevision()), false)
+		case common.Matches(msg, "revisions.jump_to_working_copy", m.keymap.JumpToWorkingCopy):
 			workingCopyIndex := m.selectRevision("@")
 			if workingCopyIndex != -1 {
 				m.cursor = workingCopyIndex
 			}
 			return m, m.updateSelection()
-		case key.Matches(msg, m.keymap.AceJump):
+		case common.Matches(msg, "revisions.ace_jump", m.keymap.AceJump):
 			// Check if an operation is active and focused first
-			if op, ok := m.op.(common.Focusable); ok && op.IsFocused() {
+			if op, ok := m.op.(common.Focusable); ok && op.IsFocused() && isKey(msg) {
 				// Let the active operation handle the key first
 				m.op, cmd = m.op.Update(msg)
 				if cmd != nil {
@@ -391,86 +548,92 @@ func (m *Model) internalUpdate(msg tea.Msg) (*Model, tea.Cmd) {
 			m.op = op
 			return m, op.Init()
 		default:
-			if op, ok := m.op.(common.Focusable); ok && op.IsFocused() {
+			if op, ok := m.op.(common.Focusable); ok && op.IsFocused() && isKey(msg) {
 				m.op, cmd = m.op.Update(msg)
 				break
 			}
 
 			switch {
-			case key.Matches(msg, m.keymap.ToggleSelect):
+			case common.Matches(msg, "revisions.toggle_select", m.keymap.ToggleSelect):
 				commit := m.rows[m.cursor].Commit
 				changeId := commit.GetChangeId()
 				item := appContext.SelectedRevision{ChangeId: changeId, CommitId: commit.CommitId}
 				m.context.ToggleCheckedItem(item)
-				immediate, _ := m.context.RunCommandImmediate(jj.GetParent(jj.NewSelectedRevisions(commit)))
-				parentIndex := m.selectRevision(string(immediate))
-				if parentIndex != -1 {
-					m.cursor = parentIndex
-				}
-			case key.Matches(msg, m.keymap.Cancel):
+				cmd = m.jumpTo(jj.GetParent(jj.NewSelectedRevisions(commit)), false)
+			case common.Matches(msg, "revisions.cancel", m.keymap.Cancel):
 				m.op = operations.NewDefault()
-			case key.Matches(msg, m.keymap.QuickSearchCycle):
-				m.cursor = m.search(m.cursor + 1)
+			case common.Matches(msg, "revisions.quick_search_cycle", m.keymap.QuickSearchCycle):
 				m.renderer.Reset()
-				return m, nil
-			case key.Matches(msg, m.keymap.Details.Mode):
+				return m, m.startSearch(m.cursor + 1)
+			case common.Matches(msg, "revisions.details", m.keymap.Details.Mode):
 				m.op = details.NewOperation(m.context, m.SelectedRevision(), m.Height)
 				return m, m.op.Init()
-			case key.Matches(msg, m.keymap.InlineDescribe.Mode):
+			case common.Matches(msg, "revisions.inline_describe", m.keymap.InlineDescribe.Mode):
 				m.op = describe.NewOperation(m.context, m.SelectedRevision().GetChangeId(), m.Width)
 				return m, m.op.Init()
-			case key.Matches(msg, m.keymap.New):
-				return m, m.context.RunCommand(jj.New(m.SelectedRevisions()), common.RefreshAndSelect("@"))
-			case key.Matches(msg, m.keymap.Commit):
-				return m, m.context.RunInteractiveCommand(jj.CommitWorkingCopy(), common.Refresh)
-			case key.Matches(msg, m.keymap.Edit, m.keymap.ForceEdit):
-				ignoreImmutable := key.Matches(msg, m.keymap.ForceEdit)
+			case common.Matches(msg, "revisions.new", m.keymap.New):
+				var parents jj.SelectedRevisions
+				if len(m.context.CheckedItems) > 0 {
+					parents = m.SelectedRevisions()
+				}
+				m.op = new_change.NewOperation(m.context, parents, new_change.TargetDestination)
+				return m, m.op.Init()
+			case common.Matches(msg, "revisions.commit", m.keymap.Commit):
+				return m.startCommit(nil)
+			case common.Matches(msg, "revisions.edit", m.keymap.Edit) || common.Matches(msg, "revisions.force_edit", m.keymap.ForceEdit):
+				ignoreImmutable := common.Matches(msg, "revisions.force_edit", m.keymap.ForceEdit)
 				return m, m.context.RunCommand(jj.Edit(m.SelectedRevision().GetChangeId(), ignoreImmutable), common.Refresh)
-			case key.Matches(msg, m.keymap.Diffedit):
+			case common.Matches(msg, "revisions.diffedit", m.keymap.Diffedit):
 				changeId := m.SelectedRevision().GetChangeId()
 				return m, m.context.RunInteractiveCommand(jj.DiffEdit(changeId), common.Refresh)
-			case key.Matches(msg, m.keymap.Absorb):
+			case common.Matches(msg, "revisions.absorb", m.keymap.Absorb):
 				changeId := m.SelectedRevision().GetChangeId()
 				return m, m.context.RunCommand(jj.Absorb(changeId), common.Refresh)
-			case key.Matches(msg, m.keymap.Abandon):
+			case common.Matches(msg, "revisions.abandon", m.keymap.Abandon):
 				selections := m.SelectedRevisions()
 				m.op = abandon.NewOperation(m.context, selections)
 				return m, m.op.Init()
-			case key.Matches(msg, m.keymap.Bookmark.Set):
+			case common.Matches(msg, "revisions.metadata", m.keymap.Metadata.Mode):
+				m.op = metadata.NewOperation(m.context, m.SelectedRevisions())
+				return m, m.op.Init()
+			case common.Matches(msg, "revisions.sign", m.keymap.Sign):
+				m.op = sign.NewOperation(m.context, m.SelectedRevisions())
+				return m, m.op.Init()
+			case common.Matches(msg, "bookmark.set", m.keymap.Bookmark.Set):
 				m.op = bookmark.NewSetBookmarkOperation(m.context, m.SelectedRevision().GetChangeId())
 				return m, m.op.Init()
-			case key.Matches(msg, m.keymap.Split):
+			case common.Matches(msg, "revisions.split", m.keymap.Split):
 				currentRevision := m.SelectedRevision().GetChangeId()
 				return m, m.context.RunInteractiveCommand(jj.Split(currentRevision, []string{}), common.Refresh)
-			case key.Matches(msg, m.keymap.Describe):
+			case common.Matches(msg, "revisions.describe", m.keymap.Describe):
 				selections := m.SelectedRevisions()
 				return m, m.context.RunInteractiveCommand(jj.Describe(selections), common.Refresh)
-			case key.Matches(msg, m.keymap.Evolog.Mode):
+			case common.Matches(msg, "revisions.evolog", m.keymap.Evolog.Mode):
 				m.op = evolog.NewOperation(m.context, m.SelectedRevision(), m.Width, m.Height)
 				return m, m.op.Init()
-			case key.Matches(msg, m.keymap.Diff):
+			case common.Matches(msg, "revisions.diff", m.keymap.Diff):
 				return m, func() tea.Msg {
 					changeId := m.SelectedRevision().GetChangeId()
 					output, _ := m.context.RunCommandImmediate(jj.Diff(changeId, ""))
 					return common.ShowDiffMsg(output)
 				}
-			case key.Matches(msg, m.keymap.Refresh):
+			case common.Matches(msg, "revisions.refresh", m.keymap.Refresh):
 				return m, common.Refresh
-			case key.Matches(msg, m.keymap.Squash.Mode):
+			case common.Matches(msg, "revisions.squash", m.keymap.Squash.Mode):
 				return m.startSquash(m.SelectedRevisions(), nil)
-			case key.Matches(msg, m.keymap.Revert.Mode):
+			case common.Matches(msg, "revisions.revert", m.keymap.Revert.Mode):
 				m.op = revert.NewOperation(m.context, m.SelectedRevisions(), revert.TargetDestination)
 				return m, m.op.Init()
-			case key.Matches(msg, m.keymap.Rebase.Mode):
+			case common.Matches(msg, "revisions.rebase", m.keymap.Rebase.Mode):
 				m.op = rebase.NewOperation(m.context, m.SelectedRevisions(), rebase.SourceRevision, rebase.TargetDestination)
 				return m, m.op.Init()
-			case key.Matches(msg, m.keymap.Duplicate.Mode):
+			case common.Matches(msg, "revisions.duplicate", m.keymap.Duplicate.Mode):
 				m.op = duplicate.NewOperation(m.context, m.SelectedRevisions(), duplicate.TargetDestination)
 				return m, m.op.Init()
-			case key.Matches(msg, m.keymap.Copy.Mode):
+			case common.Matches(msg, "revisions.copy", m.keymap.Copy.Mode):
 				m.op = copy.NewOperation(m.context, m.SelectedRevision())
 				return m, m.op.Init()
-			case key.Matches(msg, m.keymap.SetParents):
+			case common.Matches(msg, "revisions.set_parents", m.keymap.SetParents):
 				m.op = set_parents.NewModel(m.context, m.SelectedRevision())
 				return m, m.op.Init()
 			}
@@ -480,19 +643,48 @@ func (m *Model) internalUpdate(msg tea.Msg) (*Model, tea.Cmd) {
 	return m, cmd
 }
 
-func (m *Model) startSquash(selectedRevisions jj.SelectedRevisions, files []string) (*Model, tea.Cmd) {
-	parent, _ := m.context.RunCommandImmediate(jj.GetParent(selectedRevisions))
-	parentIdx := m.selectRevision(string(parent))
-	if parentIdx != -1 {
-		m.cursor = parentIdx
-	} else if m.cursor < len(m.rows)-1 {
-		m.cursor++
+func isKey(msg tea.Msg) bool {
+	_, ok := msg.(tea.KeyMsg)
+	return ok
+}
+
+// clearCheckedFiles forgets the files checked in the details view once it is closed, the operations
+// started from there are given the files they work on
+func (m *Model) clearCheckedFiles() {
+	if _, ok := m.op.(*details.Operation); ok {
+		m.context.ClearCheckedItems(reflect.TypeFor[appContext.SelectedFile]())
+	}
+}
+
+// startCommit opens the commit message editor over the working copy, files are the ones checked in the details of the working copy
+func (m *Model) startCommit(files []string) (*Model, tea.Cmd) {
+	workingCopyIndex := m.selectRevision("@")
+	if workingCopyIndex == -1 {
+		return m, m.context.RunInteractiveCommand(jj.CommitWorkingCopy(files), common.Refresh)
 	}
+	m.cursor = workingCopyIndex
+	workingCopy := m.rows[workingCopyIndex].Commit
+	m.op = describe.NewCommitOperation(m.context, workingCopy.GetChangeId(), files, m.Width)
+	return m, tea.Batch(m.updateSelection(), m.op.Init())
+}
+
+func (m *Model) startSquash(selectedRevisions jj.SelectedRevisions, files []string) (*Model, tea.Cmd) {
+	jumpToParent := m.jumpTo(jj.GetParent(selectedRevisions), true)
 	m.op = squash.NewOperation(m.context, selectedRevisions, squash.WithFiles(files))
-	return m, m.op.Init()
+	return m, tea.Batch(jumpToParent, m.op.Init())
 }
 
 func (m *Model) updateSelection() tea.Cmd {
+	var adjacent []appContext.SelectedItem
+	for _, i := range []int{m.cursor - 1, m.cursor + 1} {
+		if i >= 0 && i < len(m.rows) {
+			adjacent = append(adjacent, appContext.SelectedRevision{
+				ChangeId: m.rows[i].Commit.GetChangeId(),
+				CommitId: m.rows[i].Commit.CommitId,
+			})
+		}
+	}
+	m.context.AdjacentItems = adjacent
 	if selectedRevision := m.SelectedRevision(); selectedRevision != nil {
 		return m.context.SetSelectedItem(appContext.SelectedRevision{
 			ChangeId: selectedRevision.GetChangeId(),
@@ -530,6 +722,66 @@ func (m *Model) highlightChanges() tea.Msg {
 	return nil
 }
 
+// commitsChanged reports the working copy and the loaded commits whose rows are not the same as when they
+// were last loaded. A rewritten commit gets a new commit id, but its bookmarks, for example, can change
+// without one.
+func (m *Model) commitsChanged() tea.Cmd {
+	var commitIds []string
+	for _, row := range m.rows {
+		if row.Commit == nil || row.Commit.CommitId == "" {
+			continue
+		}
+		var text strings.Builder
+		for _, line := range row.Lines {
+			for _, segment := range line.Segments {
+				text.WriteString(segment.Text)
+			}
+		}
+		previous, seen := m.rowTexts[row.Commit.CommitId]
+		if (row.Commit.IsWorkingCopy && m.workingCopyChanged) || (seen && previous != text.String()) {
+			commitIds = append(commitIds, row.Commit.CommitId)
+		}
+		if row.Commit.IsWorkingCopy {
+			m.workingCopyChanged = false
+		}
+		m.rowTexts[row.Commit.CommitId] = text.String()
+	}
+	if len(commitIds) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		return common.CommitsChangedMsg{CommitIds: commitIds}
+	}
+}
+
+// loadSignatures reads the signature status of the loaded revisions which are not cached yet.
+// Commit ids change whenever a revision is rewritten so the cache never needs invalidating.
+func (m *Model) loadSignatures() tea.Cmd {
+	if !config.Current.Revisions.ShowSignatures {
+		return nil
+	}
+	var commitIds []string
+	for _, row := range m.rows {
+		if row.Commit == nil || row.Commit.CommitId == "" || row.Commit.IsRoot() {
+			continue
+		}
+		if _, ok := m.signatures[row.Commit.CommitId]; !ok {
+			commitIds = append(commitIds, row.Commit.CommitId)
+			// marked as known so that the following batches do not query it again while it is loading
+			m.signatures[row.Commit.CommitId] = jj.SignatureNone
+		}
+	}
+	if len(commitIds) == 0 {
+		return nil
+	}
+	return m.context.Query(jj.GetSignatures(strings.Join(commitIds, "|")), func(output []byte, err error) tea.Msg {
+		if err != nil {
+			return nil
+		}
+		return updateSignaturesMsg{signatures: jj.MatchSignatures(commitIds, jj.ParseSignaturesOutput(string(output)))}
+	})
+}
+
 func (m *Model) updateGraphRows(rows []parser.Row, selectedRevision string) {
 	if rows == nil {
 		rows = []parser.Row{}
@@ -565,7 +817,7 @@ func (m *Model) View() string {
 	if config.Current.UI.Tracer.Enabled {
 		start, end := m.renderer.FirstRowIndex, m.renderer.LastRowIndex+1 // +1 because the last row is inclusive in the view range
 		log.Println("Visible row range:", start, end, "Cursor:", m.cursor, "Total rows:", len(m.rows))
-		m.renderer.tracer = parser.NewTracer(m.rows, m.cursor, start, end)
+		m.renderer.tracer = parser.UpdateTracer(m.renderer.tracer, m.rows, m.cursor, start, end)
 	} else {
 		m.renderer.tracer = parser.NewNoopTracer()
 	}
@@ -574,6 +826,12 @@ func (m *Model) View() string {
 
 	output := m.renderer.Render(m.cursor)
 	output = m.textStyle.MaxWidth(m.Width).Render(output)
+	if m.searching != nil {
+		// the last line shows the progress of the search
+		output = lipgloss.NewStyle().MaxHeight(m.Height - 1).Render(output)
+		output = lipgloss.Place(m.Width, m.Height-1, 0, 0, output)
+		return lipgloss.JoinVertical(lipgloss.Left, output, lipgloss.NewStyle().MaxWidth(m.Width).Render(m.searchProgressView()))
+	}
 	return lipgloss.Place(m.Width, m.Height, 0, 0, output)
 }
 
@@ -602,6 +860,7 @@ func (m *Model) loadStreaming(revset string, selectedRevision string, tag uint64
 	}
 
 	m.hasMore = false
+	m.searching = nil
 
 	var notifyErrorCmd tea.Cmd
 	streamer, err := graph.NewGraphStreamer(m.context, revset)
@@ -651,26 +910,6 @@ func (m *Model) selectRevision(revision string) int {
 	return idx
 }
 
-func (m *Model) search(startIndex int) int {
-	if m.quickSearch == "" {
-		return m.cursor
-	}
-
-	n := len(m.rows)
-	for i := startIndex; i < n+startIndex; i++ {
-		c := i % n
-		row := &m.rows[c]
-		for _, line := range row.Lines {
-			for _, segment := range line.Segments {
-				if segment.Text != "" && strings.Contains(segment.Text, m.quickSearch) {
-					return c
-				}
-			}
-		}
-	}
-	return m.cursor
-}
-
 func (m *Model) CurrentOperation() operations.Operation {
 	return m.op.(operations.Operation)
 }
@@ -696,15 +935,18 @@ func New(c *appContext.MainContext) *Model {
 		textStyle:     common.DefaultPalette.Get("revisions text"),
 		dimmedStyle:   common.DefaultPalette.Get("revisions dimmed"),
 		selectedStyle: common.DefaultPalette.Get("revisions selected"),
+		signatures:    make(map[string]jj.SignatureStatus),
+		rowTexts:      make(map[string]string),
 	}
 	m.renderer = newRevisionListRenderer(&m, m.Sizeable)
 	return &m
 }
 
-func (m *Model) jumpToParent(revisions jj.SelectedRevisions) {
-	immediate, _ := m.context.RunCommandImmediate(jj.GetParent(revisions))
-	parentIndex := m.selectRevision(string(immediate))
-	if parentIndex != -1 {
-		m.cursor = parentIndex
-	}
+// jumpTo moves the cursor to the revision the query returns once it has finished, or to the next row
+// when orNext is set and the revision is not shown
+func (m *Model) jumpTo(args []string, orNext bool) tea.Cmd {
+	from, tag := m.cursor, m.tag.Load()
+	return m.context.Query(args, func(output []byte, _ error) tea.Msg {
+		return jumpToRevisionMsg{from: from, tag: tag, revision: string(output), orNext: orNext}
+	})
 }
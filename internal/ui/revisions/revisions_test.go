@@ -3,9 +3,19 @@ package revisions
 import (
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/idursun/jjui/internal/jj"
 	"github.com/idursun/jjui/internal/parser"
+	"github.com/idursun/jjui/internal/screen"
+	"github.com/idursun/jjui/internal/ui/common"
+	appContext "github.com/idursun/jjui/internal/ui/context"
+	"github.com/idursun/jjui/internal/ui/operations/details"
+	"github.com/idursun/jjui/internal/ui/operations/new_change"
+	"github.com/idursun/jjui/internal/ui/operations/rebase"
+	"github.com/idursun/jjui/internal/ui/operations/squash"
+	"github.com/idursun/jjui/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestModel_highlightChanges(t *testing.T) {
@@ -24,3 +34,75 @@ Parent commit      : nyqzpsmt 8b1e95e3 change third file
 	assert.False(t, model.rows[0].IsAffected)
 	assert.True(t, model.rows[1].IsAffected)
 }
+
+func TestModel_commitsChanged(t *testing.T) {
+	row := func(commitId string, isWorkingCopy bool, text string) parser.Row {
+		return parser.Row{
+			Commit: &jj.Commit{CommitId: commitId, IsWorkingCopy: isWorkingCopy},
+			Lines:  []*parser.GraphRowLine{{Segments: []*screen.Segment{{Text: text}}}},
+		}
+	}
+	model := Model{rowTexts: map[string]string{}}
+	model.rows = []parser.Row{row("123", true, "abc"), row("456", false, "def")}
+	assert.Nil(t, model.commitsChanged(), "expected nothing to be reported on the first load")
+
+	model.rows = []parser.Row{row("123", true, "abc"), row("456", false, "def main")}
+	cmd := model.commitsChanged()
+	require.NotNil(t, cmd)
+	assert.Equal(t, common.CommitsChangedMsg{CommitIds: []string{"456"}}, cmd())
+
+	model.workingCopyChanged = true
+	cmd = model.commitsChanged()
+	require.NotNil(t, cmd)
+	assert.Equal(t, common.CommitsChangedMsg{CommitIds: []string{"123"}}, cmd(), "expected the working copy to be reported after a refresh")
+	assert.Nil(t, model.commitsChanged())
+}
+
+func TestUpdate_NewOpensThePlacementPicker(t *testing.T) {
+	model := newSyntheticModel(t, 10)
+	model.context = test.NewTestContext(test.NewTestCommandRunner(t))
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	require.IsType(t, &new_change.Operation{}, model.op, "expected new not to run before its target is picked")
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	model, _ = model.Update(common.ActionMsg{Name: "new_placement.after"})
+	op, ok := model.op.(*new_change.Operation)
+	require.True(t, ok, "expected a placement action to open the picker")
+	assert.Equal(t, new_change.TargetAfter, op.Target)
+}
+
+func TestUpdate_ActionStartsItsMode(t *testing.T) {
+	model := newSyntheticModel(t, 10)
+	model.context = test.NewTestContext(test.NewTestCommandRunner(t))
+
+	model, _ = model.Update(common.ActionMsg{Name: "rebase.after"})
+	op, ok := model.op.(*rebase.Operation)
+	require.True(t, ok, "expected the rebase mode to start")
+	assert.Equal(t, rebase.TargetAfter, op.Target)
+
+	model, _ = model.Update(common.ActionMsg{Name: "rebase.onto"})
+	assert.Same(t, op, model.op, "expected the running rebase to handle the action")
+	assert.Equal(t, rebase.TargetDestination, op.Target)
+}
+
+func TestUpdate_RevisionsActionIsNotTakenByDetails(t *testing.T) {
+	model := newSyntheticModel(t, 10)
+	model.context = test.NewTestContext(test.NewTestCommandRunner(t))
+	model.op = details.NewOperation(model.context, model.SelectedRevision(), model.Height)
+
+	// details.squash is bound to the same key as revisions.squash
+	model, _ = model.Update(common.ActionMsg{Name: "revisions.squash"})
+	assert.IsType(t, &squash.Operation{}, model.op, "expected the selected revision to be squashed instead of the files")
+}
+
+func TestUpdate_ActionIsNotSwallowedByFocusedOperation(t *testing.T) {
+	model := newSyntheticModel(t, 10)
+	model.context = test.NewTestContext(test.NewTestCommandRunner(t))
+	model, _ = model.Update(common.ActionMsg{Name: "revisions.rebase"})
+	require.IsType(t, &rebase.Operation{}, model.op)
+
+	model, _ = model.Update(common.ActionMsg{Name: "revisions.toggle_select"})
+	assert.Len(t, model.context.CheckedItems, 1)
+	assert.IsType(t, appContext.SelectedRevision{}, model.context.CheckedItems[0])
+}
@@ -114,6 +114,10 @@ func (m *Model) SetHistory(history []string) {
 
 func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case common.ConfigReloadedMsg:
+		m.styles.promptStyle = common.DefaultPalette.Get("revset title")
+		m.styles.textStyle = common.DefaultPalette.Get("revset text")
+		return m, nil
 	case tea.KeyMsg:
 		if !m.Editing {
 			return m, nil
@@ -1,6 +1,7 @@
 package status
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -41,6 +42,8 @@ type Model struct {
 	running    bool
 	width      int
 	mode       string
+	pending    string
+	onAccept   func(input string) tea.Cmd
 	editStatus editStatus
 	history    map[string][]string
 	fuzzy      fuzzy_search.Model
@@ -99,6 +102,12 @@ func (m *Model) Init() tea.Cmd {
 func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 	km := config.Current.GetKeyMap()
 	switch msg := msg.(type) {
+	case common.ConfigReloadedMsg:
+		m.styles = newStyles()
+		m.input.TextStyle = m.styles.text
+		m.input.CompletionStyle = m.styles.dimmed
+		m.input.PlaceholderStyle = m.styles.dimmed
+		return m, nil
 	case clearMsg:
 		if m.command == string(msg) {
 			m.command = ""
@@ -119,6 +128,13 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 		return m, tea.Tick(CommandClearDuration, func(time.Time) tea.Msg {
 			return clearMsg(commandToBeCleared)
 		})
+	case common.PromptMsg:
+		m.mode = "input"
+		m.input.Prompt = msg.Prompt + ": "
+		m.onAccept = msg.Accept
+		m.editStatus = emptyEditStatus
+		m.loadEditingSuggestions()
+		return m, m.input.Focus()
 	case common.FileSearchMsg:
 		m.mode = "rev file"
 		m.input.Prompt = "> "
@@ -135,6 +151,7 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 
 			m.fuzzy = nil
 			m.editStatus = nil
+			m.onAccept = nil
 			m.input.Reset()
 			return m, cmd
 		case key.Matches(msg, accept) && m.IsFocused():
@@ -142,15 +159,19 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 			input := m.input.Value()
 			prompt := m.input.Prompt
 			fuzzy := m.fuzzy
+			onAccept := m.onAccept
 			m.saveEditingSuggestions()
 
 			m.fuzzy = nil
 			m.command = ""
 			m.editStatus = nil
+			m.onAccept = nil
 			m.mode = ""
 			m.input.Reset()
 
 			switch {
+			case onAccept != nil:
+				return m, onAccept(input)
 			case strings.HasSuffix(editMode, "file"):
 				_, cmd := fuzzy.Update(msg)
 				return m, cmd
@@ -222,6 +243,9 @@ func (m *Model) View() string {
 		commandStatusMark = m.styles.error.Render("✗ ")
 	} else if m.status == commandCompleted {
 		commandStatusMark = m.styles.success.Render("✓ ")
+	} else if m.pending != "" {
+		commandStatusMark = m.styles.shortcut.Render(m.pending) + m.styles.dimmed.Render(" …")
+		commandStatusMark = lipgloss.PlaceHorizontal(m.width, 0, commandStatusMark, lipgloss.WithWhitespaceBackground(m.styles.text.GetBackground()))
 	} else {
 		commandStatusMark = m.helpView(m.keyMap)
 		commandStatusMark = lipgloss.PlaceHorizontal(m.width, 0, commandStatusMark, lipgloss.WithWhitespaceBackground(m.styles.text.GetBackground()))
@@ -239,7 +263,11 @@ func (m *Model) View() string {
 		ret = lipgloss.JoinHorizontal(0, m.input.View(), editHelp)
 	}
 	mode := m.styles.title.Width(modeWith).Render("", m.mode)
-	ret = lipgloss.JoinHorizontal(lipgloss.Left, mode, m.styles.text.Render(" "), commandStatusMark, ret)
+	queue := m.styles.text.Render(" ")
+	if depth := m.context.QueueDepth(); depth > 0 {
+		queue = m.styles.dimmed.Render(fmt.Sprintf(" ⧗ %d ", depth))
+	}
+	ret = lipgloss.JoinHorizontal(lipgloss.Left, mode, queue, commandStatusMark, ret)
 	height := lipgloss.Height(ret)
 	return lipgloss.Place(m.width, height, 0, 0, ret, lipgloss.WithWhitespaceBackground(m.styles.text.GetBackground()))
 }
@@ -248,6 +276,11 @@ func (m *Model) SetHelp(keyMap help.KeyMap) {
 	m.keyMap = keyMap
 }
 
+// SetPendingChord shows the keys typed so far of a multi-key binding instead of the help
+func (m *Model) SetPendingChord(keys string) {
+	m.pending = keys
+}
+
 func (m *Model) SetMode(mode string) {
 	if !m.IsFocused() {
 		m.mode = mode
@@ -268,8 +301,8 @@ func (m *Model) helpView(keyMap help.KeyMap) string {
 	return help
 }
 
-func New(context *context.MainContext) Model {
-	styles := styles{
+func newStyles() styles {
+	return styles{
 		shortcut: common.DefaultPalette.Get("status shortcut"),
 		dimmed:   common.DefaultPalette.Get("status dimmed"),
 		text:     common.DefaultPalette.Get("status text"),
@@ -277,6 +310,10 @@ func New(context *context.MainContext) Model {
 		success:  common.DefaultPalette.Get("status success"),
 		error:    common.DefaultPalette.Get("status error"),
 	}
+}
+
+func New(context *context.MainContext) Model {
+	styles := newStyles()
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/charmbracelet/bubbles/help"
@@ -15,15 +16,19 @@ import (
 	"github.com/idursun/jjui/internal/jj"
 	"github.com/idursun/jjui/internal/screen"
 	"github.com/idursun/jjui/internal/ui/bookmarks"
+	"github.com/idursun/jjui/internal/ui/chord"
+	"github.com/idursun/jjui/internal/ui/command_stats"
 	"github.com/idursun/jjui/internal/ui/common"
 	"github.com/idursun/jjui/internal/ui/context"
 	customcommands "github.com/idursun/jjui/internal/ui/custom_commands"
 	"github.com/idursun/jjui/internal/ui/diff"
 	"github.com/idursun/jjui/internal/ui/exec_process"
+	"github.com/idursun/jjui/internal/ui/fix"
 	"github.com/idursun/jjui/internal/ui/git"
 	"github.com/idursun/jjui/internal/ui/helppage"
 	"github.com/idursun/jjui/internal/ui/leader"
 	"github.com/idursun/jjui/internal/ui/oplog"
+	"github.com/idursun/jjui/internal/ui/palette_inspector"
 	"github.com/idursun/jjui/internal/ui/preview"
 	"github.com/idursun/jjui/internal/ui/revisions"
 	"github.com/idursun/jjui/internal/ui/revset"
@@ -33,24 +38,39 @@ import (
 
 type Model struct {
 	*common.Sizeable
-	revisions    *revisions.Model
-	oplog        *oplog.Model
-	revsetModel  *revset.Model
-	previewModel *preview.Model
-	diff         *diff.Model
-	leader       *leader.Model
-	flash        *flash.Model
-	state        common.State
-	status       *status.Model
-	context      *context.MainContext
-	keyMap       config.KeyMappings[key.Binding]
-	stacked      tea.Model
+	revisions     *revisions.Model
+	oplog         *oplog.Model
+	revsetModel   *revset.Model
+	previewModel  *preview.Model
+	diff          *diff.Model
+	leader        *leader.Model
+	flash         *flash.Model
+	state         common.State
+	status        *status.Model
+	context       *context.MainContext
+	keyMap        config.KeyMappings[key.Binding]
+	stacked       tea.Model
+	configWatcher *config.FileWatcher
+	repoWatcher   *jj.RepoWatcher
+	chords        *chord.Resolver
+	compositor    *screen.Compositor
 }
 
 type triggerAutoRefreshMsg struct{}
 
+// repoPolledMsg is the result of checking the repository for changes made outside jjui
+type repoPolledMsg struct {
+	changed bool
+}
+
+type checkConfigMsg struct{}
+
+type configLoadedMsg struct {
+	loaded *context.LoadedConfig
+}
+
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(tea.SetWindowTitle(fmt.Sprintf("jjui - %s", m.context.Location)), m.revisions.Init(), m.scheduleAutoRefresh())
+	return tea.Batch(tea.SetWindowTitle(fmt.Sprintf("jjui - %s", m.context.Location)), m.revisions.Init(), m.scheduleAutoRefresh(), m.scheduleRepoPoll(), m.scheduleConfigCheck(), checkConfigProblems(m.context))
 }
 
 func (m Model) handleFocusInputMessage(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
@@ -113,6 +133,95 @@ func (m Model) handleFocusInputMessage(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case chord.TimeoutMsg:
+		// nothing completed the chord, so the keys are processed as they were typed
+		return m.replay(m.chords.Expire(msg))
+	case common.ActionMsg:
+		return m.handleAction(msg)
+	case tea.KeyMsg:
+		if m.acceptsChords() {
+			result, keys, cmd := m.chords.Feed(msg, func(k tea.KeyMsg) bool {
+				return key.Matches(k, m.keyMap.Cancel)
+			})
+			switch result {
+			case chord.Pending, chord.Cancelled:
+				return m, cmd
+			case chord.Completed, chord.Replayed:
+				model, replayCmd := m.replay(keys)
+				return model, tea.Batch(replayCmd, cmd)
+			}
+		}
+	}
+	return m.update(msg)
+}
+
+// replay processes the keys one by one in the order they were typed
+func (m Model) replay(keys []tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var model tea.Model = m
+	var cmds []tea.Cmd
+	for _, k := range keys {
+		var cmd tea.Cmd
+		model, cmd = model.(Model).update(k)
+		cmds = append(cmds, cmd)
+	}
+	return model, tea.Batch(cmds...)
+}
+
+// handleAction opens the view an action belongs to when it is not open yet, e.g. the git menu for
+// `git.push`, and then handles the action like the key bound to it. The modes of the operations on
+// revisions are opened by the revisions view.
+func (m Model) handleAction(msg common.ActionMsg) (tea.Model, tea.Cmd) {
+	var model tea.Model = m
+	var openCmd tea.Cmd
+	if m.needsView(msg) {
+		model, openCmd = m.update(common.ActionMsg{Name: "revisions." + msg.Group()})
+	}
+	model, cmd := model.(Model).update(msg)
+	return model, tea.Batch(openCmd, cmd)
+}
+
+// needsView reports whether the action is handled by a view that is not open
+func (m Model) needsView(msg common.ActionMsg) bool {
+	switch msg.Group() {
+	case "git":
+		_, open := m.stacked.(*git.Model)
+		return !open
+	case "bookmark":
+		// setting a bookmark is an operation of the revisions view, the rest is in the bookmarks menu
+		_, open := m.stacked.(*bookmarks.Model)
+		return !open && !common.Matches(msg, "bookmark.set", m.keyMap.Bookmark.Set)
+	case "palette":
+		_, open := m.stacked.(*palette_inspector.Model)
+		return !open
+	case "command_stats":
+		_, open := m.stacked.(*command_stats.Model)
+		return !open
+	case "oplog":
+		return m.oplog == nil
+	case "preview":
+		return !m.previewModel.Visible() && !(common.Matches(msg, "revisions.preview", m.keyMap.Preview.Mode) || common.Matches(msg, "preview.toggle_bottom", m.keyMap.Preview.ToggleBottom))
+	}
+	return false
+}
+
+// acceptsChords is false while the keys are typed into an input or go to a stacked menu
+func (m Model) acceptsChords() bool {
+	return m.leader == nil && m.stacked == nil && !m.revsetModel.Editing && !m.status.IsFocused() && !m.revisions.IsEditing()
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case checkConfigMsg:
+		return m, tea.Batch(m.scheduleConfigCheck(), m.reloadConfigIfChanged())
+	case configLoadedMsg:
+		return m.applyConfig(msg.loaded)
+	case common.SwitchThemeMsg:
+		return m.switchTheme(string(msg))
+	case common.InvokeActionMsg:
+		return m, m.context.InvokeAction(msg.Name, msg.Args)
+	}
+
 	if m, cmd, handled := m.handleFocusInputMessage(msg); handled {
 		return m, cmd
 	}
@@ -123,89 +232,117 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.FocusMsg:
 		return m, common.RefreshAndKeepSelections
-	case tea.KeyMsg:
+	case tea.KeyMsg, common.ActionMsg:
 		switch {
-		case key.Matches(msg, m.keyMap.Cancel) && m.state == common.Error:
+		case common.Matches(msg, "revisions.cancel", m.keyMap.Cancel) && m.state == common.Error:
 			m.state = common.Ready
 			return m, tea.Batch(cmds...)
-		case key.Matches(msg, m.keyMap.Cancel) && m.stacked != nil:
+		case common.Matches(msg, "revisions.cancel", m.keyMap.Cancel) && m.stacked != nil:
 			m.stacked = nil
 			return m, tea.Batch(cmds...)
-		case key.Matches(msg, m.keyMap.Cancel) && m.flash.Any():
+		case common.Matches(msg, "revisions.cancel", m.keyMap.Cancel) && m.flash.Any():
 			m.flash.DeleteOldest()
 			return m, tea.Batch(cmds...)
-		case key.Matches(msg, m.keyMap.Quit) && m.isSafeToQuit():
+		case common.Matches(msg, "revisions.quit", m.keyMap.Quit) && m.isSafeToQuit():
 			return m, tea.Quit
-		case key.Matches(msg, m.keyMap.OpLog.Mode):
+		case common.Matches(msg, "revisions.oplog", m.keyMap.OpLog.Mode):
 			m.oplog = oplog.New(m.context, m.Width, m.Height)
 			return m, m.oplog.Init()
-		case key.Matches(msg, m.keyMap.Revset) && m.revisions.InNormalMode():
+		case common.Matches(msg, "revisions.revset", m.keyMap.Revset) && m.revisions.InNormalMode():
 			m.revsetModel, _ = m.revsetModel.Update(revset.EditRevSetMsg{Clear: m.state != common.Error})
 			return m, nil
-		case key.Matches(msg, m.keyMap.Git.Mode) && m.revisions.InNormalMode():
+		case common.Matches(msg, "revisions.git", m.keyMap.Git.Mode) && m.revisions.InNormalMode():
 			m.stacked = git.NewModel(m.context, m.revisions.SelectedRevision(), m.Width, m.Height)
 			return m, m.stacked.Init()
-		case key.Matches(msg, m.keyMap.Undo) && m.revisions.InNormalMode():
+		case common.Matches(msg, "revisions.fix", m.keyMap.Fix) && m.revisions.InNormalMode():
+			if m.revisions.SelectedRevision() == nil {
+				return m, nil
+			}
+			m.stacked = fix.NewModel(m.context, m.revisions.SelectedRevisions(), m.Width, m.Height)
+			return m, m.stacked.Init()
+		case common.Matches(msg, "revisions.undo", m.keyMap.Undo) && m.revisions.InNormalMode():
 			m.stacked = undo.NewModel(m.context)
 			cmds = append(cmds, m.stacked.Init())
 			return m, tea.Batch(cmds...)
-		case key.Matches(msg, m.keyMap.Bookmark.Mode) && m.revisions.InNormalMode():
+		case common.Matches(msg, "revisions.bookmark", m.keyMap.Bookmark.Mode) && m.revisions.InNormalMode():
 			changeIds := m.revisions.GetCommitIds()
 			m.stacked = bookmarks.NewModel(m.context, m.revisions.SelectedRevision(), changeIds, m.Width, m.Height)
 			cmds = append(cmds, m.stacked.Init())
 			return m, tea.Batch(cmds...)
-		case key.Matches(msg, m.keyMap.Help):
+		case common.Matches(msg, "revisions.palette", m.keyMap.Palette.Mode) && m.revisions.InNormalMode():
+			m.stacked = palette_inspector.New(m.context, m.Width-2, m.Height-2)
+			return m, m.stacked.Init()
+		case common.Matches(msg, "revisions.command_stats", m.keyMap.CommandStats.Mode) && m.revisions.InNormalMode():
+			m.stacked = command_stats.New(m.context, m.Width-2, m.Height-2)
+			return m, m.stacked.Init()
+		case common.Matches(msg, "revisions.help", m.keyMap.Help):
 			cmds = append(cmds, common.ToggleHelp)
 			return m, tea.Batch(cmds...)
-		case key.Matches(msg, m.keyMap.Preview.Mode, m.keyMap.Preview.ToggleBottom):
-			if key.Matches(msg, m.keyMap.Preview.ToggleBottom) {
+		case common.Matches(msg, "revisions.preview", m.keyMap.Preview.Mode) || common.Matches(msg, "preview.toggle_bottom", m.keyMap.Preview.ToggleBottom):
+			if common.Matches(msg, "preview.toggle_bottom", m.keyMap.Preview.ToggleBottom) {
 				m.previewModel.TogglePosition()
 				if m.previewModel.Visible() {
+					cmds = append(cmds, preview.Resized)
 					return m, tea.Batch(cmds...)
 				}
 			}
 			m.previewModel.ToggleVisible()
 			cmds = append(cmds, common.SelectionChanged)
 			return m, tea.Batch(cmds...)
-		case key.Matches(msg, m.keyMap.Preview.Expand) && m.previewModel.Visible():
+		case common.Matches(msg, "preview.expand", m.keyMap.Preview.Expand) && m.previewModel.Visible():
 			m.previewModel.Expand()
+			cmds = append(cmds, preview.Resized)
 			return m, tea.Batch(cmds...)
-		case key.Matches(msg, m.keyMap.Preview.Shrink) && m.previewModel.Visible():
+		case common.Matches(msg, "preview.shrink", m.keyMap.Preview.Shrink) && m.previewModel.Visible():
 			m.previewModel.Shrink()
+			cmds = append(cmds, preview.Resized)
 			return m, tea.Batch(cmds...)
-		case key.Matches(msg, m.keyMap.CustomCommands):
+		case common.Matches(msg, "revisions.custom_commands", m.keyMap.CustomCommands):
 			m.stacked = customcommands.NewModel(m.context, m.Width, m.Height)
 			cmds = append(cmds, m.stacked.Init())
 			return m, tea.Batch(cmds...)
-		case key.Matches(msg, m.keyMap.Leader):
+		case common.Matches(msg, "revisions.leader", m.keyMap.Leader):
 			m.leader = leader.New(m.context)
 			cmds = append(cmds, leader.InitCmd)
 			return m, tea.Batch(cmds...)
-		case key.Matches(msg, m.keyMap.FileSearch.Toggle):
+		case common.Matches(msg, "file_search.toggle", m.keyMap.FileSearch.Toggle):
 			rev := m.revisions.SelectedRevision()
 			if rev == nil {
 				// noop if current revset does not exist (#264)
 				return m, nil
 			}
-			out, _ := m.context.RunCommandImmediate(jj.FilesInRevision(rev))
-			return m, common.FileSearch(m.context.CurrentRevset, m.previewModel.Visible(), rev, out)
-		case key.Matches(msg, m.keyMap.QuickSearch) && m.oplog != nil:
+			revset, previewShown := m.context.CurrentRevset, m.previewModel.Visible()
+			return m, m.context.Query(jj.FilesInRevision(rev), func(out []byte, _ error) tea.Msg {
+				return common.FileSearch(revset, previewShown, rev, out)()
+			})
+		case common.Matches(msg, "revisions.quick_search", m.keyMap.QuickSearch) && m.oplog != nil:
 			// HACK: prevents quick search from activating in op log view
 			return m, nil
-		case key.Matches(msg, m.keyMap.Suspend):
+		case common.Matches(msg, "revisions.suspend", m.keyMap.Suspend):
 			return m, tea.Suspend
 		default:
+			// custom commands are invoked by their names rather than as actions of a binding
+			keyMsg, ok := msg.(tea.KeyMsg)
+			if !ok {
+				break
+			}
 			for _, command := range m.context.CustomCommands {
 				if !command.IsApplicableTo(m.context.SelectedItem) {
 					continue
 				}
-				if key.Matches(msg, command.Binding()) {
+				if key.Matches(keyMsg, command.Binding()) {
 					return m, command.Prepare(m.context)
 				}
 			}
 		}
 	case common.ExecMsg:
 		return m, exec_process.ExecLine(m.context, msg)
+	case common.ConfirmMsg:
+		m.stacked = customcommands.NewConfirmModel(msg)
+		return m, m.stacked.Init()
+	case common.ShowMenuMsg:
+		m.stacked = customcommands.NewMenuModel(m.context, msg, m.Width, m.Height)
+		return m, m.stacked.Init()
 	case common.ToggleHelpMsg:
 		if m.stacked == nil {
 			m.stacked = helppage.New(m.context)
@@ -220,12 +357,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case common.ShowDiffMsg:
 		m.diff = diff.New(string(msg), m.Width, m.Height)
 		return m, m.diff.Init()
+	case common.CommitsChangedMsg:
+		// the cached previews are dropped even while the preview is hidden
+		m.previewModel, cmd = m.previewModel.Update(msg)
+		return m, cmd
+	case common.ShowPreviewContentMsg:
+		m.previewModel.SetVisible(true)
+		m.previewModel, cmd = m.previewModel.Update(msg)
+		return m, cmd
 	case common.UpdateRevisionsSuccessMsg:
 		m.state = common.Ready
+		cmds = append(cmds, m.syncRepoWatcher())
 	case triggerAutoRefreshMsg:
 		return m, tea.Batch(m.scheduleAutoRefresh(), func() tea.Msg {
 			return common.AutoRefreshMsg{}
 		})
+	case repoPolledMsg:
+		if msg.changed {
+			return m, tea.Batch(m.scheduleRepoPoll(), common.RefreshAndKeepSelections)
+		}
+		return m, m.scheduleRepoPoll()
 	case common.UpdateRevSetMsg:
 		m.context.CurrentRevset = string(msg)
 		m.revsetModel.AddToHistory(m.context.CurrentRevset)
@@ -299,6 +450,7 @@ func (m Model) updateStatus() {
 		m.status.SetHelp(m.revisions)
 		m.status.SetMode(m.revisions.CurrentOperation().Name())
 	}
+	m.status.SetPendingChord(m.chords.Pending())
 }
 
 func (m Model) View() string {
@@ -337,26 +489,38 @@ func (m Model) View() string {
 		}
 	}
 
+	// the overlays are drawn on top of the screen by the compositor, which keeps the parsed views between frames
+	var layers []screen.Layer
 	if m.stacked != nil {
 		stackedView := m.stacked.View()
 		w, h := lipgloss.Size(stackedView)
 		sx := (m.Width - w) / 2
 		sy := (m.Height - h) / 2
-		centerView = screen.Stacked(centerView, stackedView, sx, sy)
+		layers = append(layers, screen.Layer{Content: stackedView, X: sx, Y: sy + topViewHeight})
+	}
+
+	if m.leader != nil {
+		centerHeight := lipgloss.Height(centerView)
+		m.leader.SetWidth(m.Width)
+		m.leader.SetHeight(centerHeight)
+		if whichKeyView := m.leader.View(); whichKeyView != "" {
+			w, h := lipgloss.Size(whichKeyView)
+			layers = append(layers, screen.Layer{Content: whichKeyView, X: (m.Width - w) / 2, Y: (centerHeight-h)/2 + topViewHeight})
+		}
 	}
 
 	full := lipgloss.JoinVertical(0, topView, centerView, footer)
 	flashMessageView := m.flash.View()
 	if flashMessageView != "" {
 		mw, mh := lipgloss.Size(flashMessageView)
-		full = screen.Stacked(full, flashMessageView, m.Width-mw, m.Height-mh-1)
+		layers = append(layers, screen.Layer{Content: flashMessageView, X: m.Width - mw, Y: m.Height - mh - 1})
 	}
 	statusFuzzyView := m.status.FuzzyView()
 	if statusFuzzyView != "" {
 		_, mh := lipgloss.Size(statusFuzzyView)
-		full = screen.Stacked(full, statusFuzzyView, 0, m.Height-mh-1)
+		layers = append(layers, screen.Layer{Content: statusFuzzyView, X: 0, Y: m.Height - mh - 1})
 	}
-	return full
+	return m.compositor.Compose(full, layers...)
 }
 
 func (m Model) renderLeftView(footerHeight int, topViewHeight int, bottomPreviewHeight int) string {
@@ -394,6 +558,147 @@ func (m Model) scheduleAutoRefresh() tea.Cmd {
 	return nil
 }
 
+// scheduleRepoPoll checks the repository for new operations and working copy changes after the interval
+func (m Model) scheduleRepoPoll() tea.Cmd {
+	if m.repoWatcher == nil {
+		return nil
+	}
+	watcher := m.repoWatcher
+	interval := time.Duration(max(config.Current.UI.RepoWatcher.Interval, 50)) * time.Millisecond
+	return tea.Tick(interval, func(now time.Time) tea.Msg {
+		return repoPolledMsg{changed: watcher.Poll(now)}
+	})
+}
+
+// syncRepoWatcher marks the state of the repository as seen once the revisions are loaded, so that the
+// operations run by jjui itself do not cause a second refresh
+func (m Model) syncRepoWatcher() tea.Cmd {
+	if m.repoWatcher == nil {
+		return nil
+	}
+	watcher := m.repoWatcher
+	return func() tea.Msg {
+		watcher.Sync(time.Now())
+		return nil
+	}
+}
+
+func (m Model) scheduleConfigCheck() tea.Cmd {
+	interval := config.Current.UI.ConfigReloadInterval
+	if interval > 0 {
+		return tea.Tick(time.Duration(interval)*time.Second, func(time.Time) tea.Msg {
+			return checkConfigMsg{}
+		})
+	}
+	return nil
+}
+
+// newChordResolver collects the multi-key bindings of the key mappings and the custom commands
+func newChordResolver(ctx *context.MainContext) *chord.Resolver {
+	chords := config.Current.Keys.Chords()
+	for _, command := range ctx.CustomCommands {
+		for _, k := range command.Binding().Keys() {
+			if config.IsChord(k) && !slices.Contains(chords, k) {
+				chords = append(chords, k)
+			}
+		}
+	}
+	return chord.New(chords, time.Duration(config.Current.UI.ChordTimeout)*time.Millisecond)
+}
+
+// newConfigWatcher watches the user config, the active theme and the repository overlays
+func newConfigWatcher(ctx *context.MainContext) *config.FileWatcher {
+	files := config.Current.WatchedFiles(ctx.DarkBackground)
+	files = append(files, config.RepoConfigFiles(ctx.Location)...)
+	return config.NewFileWatcher(files)
+}
+
+// reloadConfigIfChanged loads the configuration again when config.toml or the theme file has changed.
+// Errors are reported and the current configuration is kept.
+func (m Model) reloadConfigIfChanged() tea.Cmd {
+	watcher := m.configWatcher
+	ctx := m.context
+	return func() tea.Msg {
+		if !watcher.Changed() {
+			return nil
+		}
+		layers, err := ctx.ConfigLayers()
+		if err != nil {
+			return common.CommandCompletedMsg{Err: fmt.Errorf("config reload failed, keeping the current config: %w", err)}
+		}
+		loaded, err := context.LoadConfig(layers, ctx.DarkBackground)
+		if err != nil {
+			return common.CommandCompletedMsg{Err: fmt.Errorf("config reload failed, keeping the current config: %w", err)}
+		}
+		return configLoadedMsg{loaded: loaded}
+	}
+}
+
+// checkConfigProblems warns about the problems that `jjui --check-config` would report
+func checkConfigProblems(ctx *context.MainContext) tea.Cmd {
+	return func() tea.Msg {
+		layers, err := ctx.ConfigLayers()
+		if err != nil {
+			return nil
+		}
+		issues, err := context.ValidateLayers(layers)
+		if err != nil || len(issues) == 0 {
+			return nil
+		}
+		return common.CommandCompletedMsg{Err: fmt.Errorf("config has %d problem(s), run `jjui --check-config` for details", len(issues))}
+	}
+}
+
+func (m Model) applyConfig(loaded *context.LoadedConfig) (tea.Model, tea.Cmd) {
+	m.context.ApplyConfig(loaded)
+	m.keyMap = config.Current.GetKeyMap()
+	// the theme file may have changed
+	m.configWatcher = newConfigWatcher(m.context)
+	m.chords = newChordResolver(m.context)
+
+	cmds := m.broadcastConfigReloaded()
+	var cmd tea.Cmd
+	m.flash, cmd = m.flash.Update(common.CommandCompletedMsg{Output: "Configuration reloaded"})
+	cmds = append(cmds, cmd, checkConfigProblems(m.context))
+	return m, tea.Batch(cmds...)
+}
+
+func (m Model) switchTheme(name string) (tea.Model, tea.Cmd) {
+	if err := m.context.SwitchTheme(name); err != nil {
+		return m, func() tea.Msg {
+			return common.CommandCompletedMsg{Err: err}
+		}
+	}
+	m.configWatcher = newConfigWatcher(m.context)
+	return m, tea.Batch(m.broadcastConfigReloaded()...)
+}
+
+// broadcastConfigReloaded lets the models rebuild their key maps and styles
+func (m *Model) broadcastConfigReloaded() []tea.Cmd {
+	var cmds []tea.Cmd
+	var cmd tea.Cmd
+	reloaded := common.ConfigReloadedMsg{}
+	m.revisions, cmd = m.revisions.Update(reloaded)
+	cmds = append(cmds, cmd)
+	if m.oplog != nil {
+		m.oplog, cmd = m.oplog.Update(reloaded)
+		cmds = append(cmds, cmd)
+	}
+	if m.stacked != nil {
+		m.stacked, cmd = m.stacked.Update(reloaded)
+		cmds = append(cmds, cmd)
+	}
+	m.previewModel, cmd = m.previewModel.Update(reloaded)
+	cmds = append(cmds, cmd)
+	m.revsetModel, cmd = m.revsetModel.Update(reloaded)
+	cmds = append(cmds, cmd)
+	m.status, cmd = m.status.Update(reloaded)
+	cmds = append(cmds, cmd)
+	m.flash, cmd = m.flash.Update(reloaded)
+	cmds = append(cmds, cmd)
+	return cmds
+}
+
 func (m Model) isSafeToQuit() bool {
 	if m.stacked != nil {
 		return false
@@ -412,14 +717,29 @@ func New(c *context.MainContext) tea.Model {
 	previewModel := preview.New(c)
 	statusModel := status.New(c)
 	return Model{
-		Sizeable:     &common.Sizeable{Width: 0, Height: 0},
-		context:      c,
-		keyMap:       config.Current.GetKeyMap(),
-		state:        common.Loading,
-		revisions:    revisionsModel,
-		previewModel: &previewModel,
-		status:       &statusModel,
-		revsetModel:  revset.New(c),
-		flash:        flash.New(c),
+		Sizeable:      &common.Sizeable{Width: 0, Height: 0},
+		context:       c,
+		keyMap:        config.Current.GetKeyMap(),
+		state:         common.Loading,
+		revisions:     revisionsModel,
+		previewModel:  &previewModel,
+		status:        &statusModel,
+		revsetModel:   revset.New(c),
+		flash:         flash.New(c),
+		configWatcher: newConfigWatcher(c),
+		repoWatcher:   newRepoWatcher(c),
+		chords:        newChordResolver(c),
+		compositor:    screen.NewCompositor(),
+	}
+}
+
+// newRepoWatcher watches the repository for changes made outside jjui when it is enabled
+func newRepoWatcher(ctx *context.MainContext) *jj.RepoWatcher {
+	watcherConfig := config.Current.UI.RepoWatcher
+	if !watcherConfig.Enabled {
+		return nil
 	}
+	debounce := time.Duration(watcherConfig.Debounce) * time.Millisecond
+	filesInterval := time.Duration(watcherConfig.WorkingCopyInterval) * time.Millisecond
+	return jj.NewRepoWatcher(ctx.Location, ctx.RunCommandImmediate, watcherConfig.MaxFiles, debounce, filesInterval)
 }
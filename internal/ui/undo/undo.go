@@ -11,9 +11,13 @@ import (
 )
 
 type Model struct {
+	context      *context.MainContext
 	confirmation *confirmation.Model
 }
 
+// lastOperationMsg carries the operation that will be undone
+type lastOperationMsg string
+
 func (m Model) ShortHelp() []key.Binding {
 	return m.confirmation.ShortHelp()
 }
@@ -23,10 +27,17 @@ func (m Model) FullHelp() [][]key.Binding {
 }
 
 func (m Model) Init() tea.Cmd {
-	return m.confirmation.Init()
+	loadLastOperation := m.context.Query(jj.OpLog(1), func(output []byte, _ error) tea.Msg {
+		return lastOperationMsg(output)
+	})
+	return tea.Batch(loadLastOperation, m.confirmation.Init())
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(lastOperationMsg); ok {
+		m.confirmation = newConfirmation(m.context, string(msg))
+		return m, nil
+	}
 	var cmd tea.Cmd
 	m.confirmation, cmd = m.confirmation.Update(msg)
 	return m, cmd
@@ -37,8 +48,14 @@ func (m Model) View() string {
 }
 
 func NewModel(context *context.MainContext) Model {
-	output, _ := context.RunCommandImmediate(jj.OpLog(1))
-	lastOperation := lipgloss.NewStyle().PaddingBottom(1).Render(string(output))
+	return Model{
+		context:      context,
+		confirmation: newConfirmation(context, "loading the last operation…"),
+	}
+}
+
+func newConfirmation(context *context.MainContext, lastOperation string) *confirmation.Model {
+	lastOperation = lipgloss.NewStyle().PaddingBottom(1).Render(lastOperation)
 	model := confirmation.New(
 		[]string{lastOperation, "Are you sure you want to undo last change?"},
 		confirmation.WithStylePrefix("undo"),
@@ -46,7 +63,5 @@ func NewModel(context *context.MainContext) Model {
 		confirmation.WithOption("No", common.Close, key.NewBinding(key.WithKeys("n", "esc"), key.WithHelp("n/esc", "no"))),
 	)
 	model.Styles.Border = common.DefaultPalette.GetBorder("undo border", lipgloss.NormalBorder()).Padding(1)
-	return Model{
-		confirmation: model,
-	}
+	return model
 }
@@ -3,6 +3,7 @@ package test
 import (
 	"bufio"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -83,3 +84,41 @@ func (l *LogBuilder) Author(value string) {
 func (l *LogBuilder) Bookmarks(value string) {
 	fmt.Fprintf(&l.w, " %s ", styles[bookmark].Render(value))
 }
+
+// changeIdAlphabet is the alphabet jj uses for change ids
+const changeIdAlphabet = "zyxwvutsrqponmlk"
+
+func syntheticId(n int, alphabet string) string {
+	id := make([]byte, 8)
+	for i := range id {
+		id[len(id)-1-i] = alphabet[n%16]
+		n /= 16
+	}
+	return string(id)
+}
+
+// SyntheticLog builds the colored output of `jj log` with the given number of commits. Every tenth commit
+// is on a side branch that merges back into the main line, so that the graph has more than one lane.
+func SyntheticLog(commits int) string {
+	lipgloss.SetColorProfile(termenv.ANSI)
+	var w strings.Builder
+	for i := 0; i < commits; i++ {
+		changeId := syntheticId(i, changeIdAlphabet)
+		commitId := syntheticId(i*7919, "0123456789abcdef")
+		node, edge, description := "○  ", "│  ", "commit "+strconv.Itoa(i)
+		switch {
+		case i == 0:
+			node, description = "@  ", "working copy"
+		case i%10 == 5:
+			node, edge, description = "│ ○  ", "├─╯  ", "side branch "+strconv.Itoa(i)
+		}
+		fmt.Fprintf(&w, "%s%s%s %s 2025-01-01 12:00:00 %s", node, styles[id].Render(changeId[:1]), styles[id].Render(changeId[1:]),
+			styles[author].Render("some@author"), styles[id].Render(commitId))
+		if i%10 == 0 {
+			fmt.Fprintf(&w, " %s", styles[bookmark].Render("release-"+strconv.Itoa(i)))
+		}
+		fmt.Fprintf(&w, "\n%s%s\n", edge, description)
+	}
+	fmt.Fprintf(&w, "◆  %s%s root() %s\n", styles[id].Render("z"), styles[id].Render("zzzzzzz"), styles[id].Render("00000000"))
+	return w.String()
+}